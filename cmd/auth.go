@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/credstore"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/spf13/cobra"
+)
+
+func pluginAuth(cmd *cobra.Command, args []string) {
+	if err := runAuth(cmd, args[0]); err != nil {
+		logger.Error("%v", err)
+		os.Exit(1)
+	}
+}
+
+func runAuth(cmd *cobra.Command, target string) error {
+	path, err := resolvePluginFile(target)
+	if err != nil {
+		return err
+	}
+	name := host.PluginName(path)
+	store, err := tokenStore()
+	if err != nil {
+		return err
+	}
+	if logout, _ := cmd.Flags().GetBool("logout"); logout {
+		if err := store.Delete(name); err != nil {
+			return err
+		}
+		logger.Info("Removed stored token for %s", name)
+		return nil
+	}
+
+	meta, err := pluginMeta(cmd.Context(), path)
+	if err != nil {
+		return err
+	}
+	if meta.AuthType != models.AuthTypeOAuth2 {
+		return fmt.Errorf("%s uses auth_type %q; 'uagplugin auth' is for oauth2 plugins (try 'uagplugin call %s auth')", name, meta.AuthType, target)
+	}
+
+	ctx := cmd.Context()
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+	if timeoutSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
+		defer cancel()
+	}
+
+	var tok *host.OAuth2Token
+	if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+		old, err := store.Load(name)
+		if err != nil {
+			return err
+		}
+		if old.RefreshToken == "" {
+			return fmt.Errorf("the stored token for %s has no refresh token; run 'uagplugin auth %s' again", name, target)
+		}
+		c, err := host.NewOAuth2Client(meta.OAuth2, old.ClientID, old.ClientSecret)
+		if err != nil {
+			return err
+		}
+		if tok, err = c.Refresh(ctx, old.RefreshToken); err != nil {
+			return err
+		}
+	} else {
+		clientID, _ := cmd.Flags().GetString("client-id")
+		clientSecret, _ := cmd.Flags().GetString("client-secret")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		port, _ := cmd.Flags().GetInt("port")
+		noBrowser, _ := cmd.Flags().GetBool("no-browser")
+		c, err := host.NewOAuth2Client(meta.OAuth2, clientID, clientSecret)
+		if err != nil {
+			return err
+		}
+		tok, err = host.AuthorizeOAuth2(ctx, c, host.AuthorizeOptions{
+			Scopes: scopes,
+			Port:   port,
+			OpenURL: func(u string) error {
+				fmt.Fprintf(os.Stderr, "Open this URL to authorize %s:\n\n  %s\n\nWaiting for the redirect...\n", name, u)
+				if !noBrowser {
+					if err := openBrowser(u); err != nil {
+						logger.Debug("Failed to open a browser: %v", err)
+					}
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := store.Save(name, tok); err != nil {
+		return fmt.Errorf("failed to store the token: %w", err)
+	}
+	creds := tok.Credentials()
+	if err := host.ValidateAPICredentials(meta, &creds); err != nil {
+		logger.Warn("%v", err)
+	}
+	if exp := tok.Expiry(); !exp.IsZero() {
+		logger.Info("Stored token for %s (expires %s)", name, exp.Local().Format(time.RFC3339))
+	} else {
+		logger.Info("Stored token for %s", name)
+	}
+	return nil
+}
+
+// withOAuth2Token merges the stored token of an oauth2 plugin under auth,
+// refreshing it first if it is about to expire. Without a stored token the
+// explicit credentials are used as they are.
+func withOAuth2Token(ctx context.Context, store host.TokenStore, meta *models.MetaData, name string, auth models.AuthCredentials) (models.AuthCredentials, error) {
+	creds, err := host.OAuth2Credentials(ctx, store, name, meta, auth)
+	if errors.Is(err, host.ErrNoToken) && len(auth) > 0 {
+		return auth, nil
+	}
+	return creds, err
+}
+
+// pluginMeta loads the plugin at path just long enough to read its metadata.
+func pluginMeta(ctx context.Context, path string) (*models.MetaData, error) {
+	p, err := host.Open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+	meta, err := p.MetaContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: meta: %w", path, err)
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("%s returned no metadata", path)
+	}
+	return meta, nil
+}
+
+// tokenProfile is the credstore profile that holds a plugin's OAuth2 token.
+const tokenProfile = "oauth2"
+
+// tokenStore returns the encrypted store for OAuth2 tokens in
+// ~/.uag/plugins/tokens. It shares the credential store's passphrase source.
+func tokenStore() (encryptedTokenStore, error) {
+	baseDir, err := utils.GetBaseDir()
+	if err != nil {
+		return encryptedTokenStore{}, err
+	}
+	dir := filepath.Join(baseDir, "tokens")
+	return encryptedTokenStore{store: encryptedStore(dir), legacy: host.FileTokenStore{Dir: dir}}, nil
+}
+
+// encryptedTokenStore keeps each plugin's token, client secret included, in
+// a credstore profile. Plaintext tokens written by earlier releases are
+// moved into it the first time they are loaded.
+type encryptedTokenStore struct {
+	store  *credstore.Store
+	legacy host.FileTokenStore
+}
+
+// unlock asks for the passphrase before requests share the store, when one
+// of the oauth2 plugins in metas has a stored token, plaintext ones included
+// since loading them saves them encrypted.
+func (s encryptedTokenStore) unlock(metas map[string]*models.MetaData) error {
+	for name, meta := range metas {
+		if meta == nil || meta.AuthType != models.AuthTypeOAuth2 {
+			continue
+		}
+		entries, _ := s.store.List(name)
+		if _, err := s.legacy.Load(name); len(entries) > 0 || err == nil {
+			return s.store.Unlock()
+		}
+	}
+	return nil
+}
+
+func (s encryptedTokenStore) Load(name string) (*host.OAuth2Token, error) {
+	tok := &host.OAuth2Token{}
+	err := s.store.Load(name, tokenProfile, tok)
+	if err == nil {
+		return tok, nil
+	}
+	if !errors.Is(err, credstore.ErrNotFound) {
+		return nil, err
+	}
+	if tok, err = s.legacy.Load(name); err != nil {
+		return nil, err
+	}
+	if err := s.Save(name, tok); err != nil {
+		return nil, err
+	}
+	return tok, s.legacy.Delete(name)
+}
+
+func (s encryptedTokenStore) Save(name string, tok *host.OAuth2Token) error {
+	return s.store.Save(name, tokenProfile, tok)
+}
+
+func (s encryptedTokenStore) Delete(name string) error {
+	if err := s.store.Remove(name, tokenProfile); err != nil && !errors.Is(err, credstore.ErrNotFound) {
+		return err
+	}
+	return s.legacy.Delete(name)
+}
+
+func openBrowser(u string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", u)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		c = exec.Command("xdg-open", u)
+	}
+	return c.Start()
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/output"
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/spf13/cobra"
+)
+
+var callMethods = []string{"meta", "health", "auth", "contacts", "ledger"}
+
+func pluginCall(cmd *cobra.Command, args []string) {
+	if err := runCall(cmd, args[0], strings.ToLower(args[1])); err != nil {
+		logger.Error("%v", err)
+		os.Exit(1)
+	}
+}
+
+func runCall(cmd *cobra.Command, target, method string) error {
+	format, err := output.ParseFormat(mustString(cmd, "output"))
+	if err != nil {
+		return err
+	}
+	path, err := resolvePluginFile(target)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if timeoutSec, _ := cmd.Flags().GetInt("timeout"); timeoutSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
+		defer cancel()
+	}
+	p, err := host.Open(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	auth := models.AuthCredentials{}
+	if err := readJSONArg(mustString(cmd, "auth"), &auth); err != nil {
+		return fmt.Errorf("invalid --auth: %w", err)
+	}
+	if method == "contacts" || method == "ledger" {
+		if profile := mustString(cmd, "profile"); profile != "" {
+			store, err := credStore()
+			if err != nil {
+				return err
+			}
+			if auth, err = storedCredentials(store, profile, host.PluginName(path), auth); err != nil {
+				return err
+			}
+		}
+		meta, err := p.MetaContext(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: meta: %w", host.PluginName(path), err)
+		}
+		tokens, err := tokenStore()
+		if err != nil {
+			return err
+		}
+		if auth, err = withOAuth2Token(ctx, tokens, meta, host.PluginName(path), auth); err != nil {
+			return err
+		}
+	}
+	allPages, _ := cmd.Flags().GetBool("all-pages")
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+
+	var result any
+	switch method {
+	case "meta":
+		if result, err = p.MetaContext(ctx); err != nil {
+			return fmt.Errorf("%s: %w", host.PluginName(path), err)
+		}
+	case "health":
+		result = map[string]string{"status": p.HealthContext(ctx)}
+	case "auth":
+		params, err := authParamsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if result, err = p.AuthenticateContext(ctx, params); err != nil {
+			return fmt.Errorf("%s: %w", host.PluginName(path), err)
+		}
+	case "contacts":
+		params, err := contactParamsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if allPages {
+			result, err = p.AllContacts(ctx, auth, params, maxPages)
+		} else {
+			result, err = p.ContactsContext(ctx, auth, params)
+		}
+		if err != nil {
+			return err
+		}
+	case "ledger":
+		params, err := ledgerParamsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		var l *models.Ledger
+		if allPages {
+			l, err = p.AllLedger(ctx, auth, params, maxPages)
+		} else {
+			l, err = p.LedgerContext(ctx, auth, params)
+		}
+		if err != nil {
+			return err
+		}
+		if err := host.FillBalances(l, params); err != nil {
+			return fmt.Errorf("%s: balances: %w", host.PluginName(path), err)
+		}
+		result = l
+	default:
+		return fmt.Errorf("unknown method %q (choose %s)", method, strings.Join(callMethods, ", "))
+	}
+	return output.Write(os.Stdout, result, format)
+}
+
+// resolvePluginFile accepts a compiled plugin path, the name of an installed
+// plugin or a remote target such as grpc://host:port/name.
+func resolvePluginFile(target string) (string, error) {
+	if host.IsRemote(target) {
+		return target, nil
+	}
+	if host.IsPluginFile(target) {
+		if _, err := os.Stat(target); err != nil {
+			return "", err
+		}
+		return filepath.Abs(target)
+	}
+	if reg, err := registry.Load(); err == nil {
+		if e, ok := reg.Get(target); ok && e.Artifact != "" {
+			return e.Artifact, nil
+		}
+	}
+	_, buildDir, err := utils.GetBaseAndBuildDir()
+	if err != nil {
+		return "", err
+	}
+	for _, suffix := range []string{host.NativeSuffix, host.RPCSuffix} {
+		p := filepath.Join(buildDir, target+suffix)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("plugin %q is not installed and is not a plugin file", target)
+}
+
+// readJSONArg decodes a flag value that is either inline JSON or @path to a
+// JSON file. An empty value leaves v untouched.
+func readJSONArg(s string, v any) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	data := []byte(s)
+	if path, ok := strings.CutPrefix(s, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+func mustString(cmd *cobra.Command, name string) string {
+	s, _ := cmd.Flags().GetString(name)
+	return s
+}
+
+// keyValues parses repeated key=value flags.
+func keyValues(cmd *cobra.Command, name string) (map[string]string, error) {
+	pairs, _ := cmd.Flags().GetStringArray(name)
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, kv := range pairs {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected key=value", name, kv)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// applyCommonFlags overrides the pagination fields set on the command line.
+func applyCommonFlags(cmd *cobra.Command, c *models.CommonParams) error {
+	f := cmd.Flags()
+	if f.Changed("cursor") {
+		c.Cursor, _ = f.GetString("cursor")
+	}
+	if f.Changed("limit") {
+		c.Limit, _ = f.GetInt("limit")
+	}
+	if f.Changed("page") {
+		c.Page, _ = f.GetInt("page")
+	}
+	if f.Changed("desc") {
+		c.SortDescending, _ = f.GetBool("desc")
+	}
+	extras, err := keyValues(cmd, "extra")
+	if err != nil {
+		return err
+	}
+	for k, v := range extras {
+		if c.Extras == nil {
+			c.Extras = map[string]string{}
+		}
+		c.Extras[k] = v
+	}
+	return nil
+}
+
+func contactParamsFromFlags(cmd *cobra.Command) (models.ContactQueryParams, error) {
+	var params models.ContactQueryParams
+	if err := readJSONArg(mustString(cmd, "params"), &params); err != nil {
+		return params, fmt.Errorf("invalid --params: %w", err)
+	}
+	f := cmd.Flags()
+	if f.Changed("search") {
+		params.Search, _ = f.GetString("search")
+	}
+	if f.Changed("ids") {
+		params.SearchIDs, _ = f.GetStringSlice("ids")
+	}
+	return params, applyCommonFlags(cmd, &params.CommonParams)
+}
+
+func ledgerParamsFromFlags(cmd *cobra.Command) (models.LedgerQueryParams, error) {
+	var params models.LedgerQueryParams
+	if err := readJSONArg(mustString(cmd, "params"), &params); err != nil {
+		return params, fmt.Errorf("invalid --params: %w", err)
+	}
+	f := cmd.Flags()
+	if f.Changed("customer") {
+		params.CustomerID, _ = f.GetString("customer")
+	}
+	if f.Changed("start") {
+		params.StartDate, _ = f.GetString("start")
+	}
+	if f.Changed("end") {
+		params.EndDate, _ = f.GetString("end")
+	}
+	if f.Changed("doc-type") {
+		types, _ := f.GetStringSlice("doc-type")
+		params.DocTypes = nil
+		for _, t := range types {
+			params.DocTypes = append(params.DocTypes, models.DocType(t))
+		}
+	}
+	if err := params.Validate(); err != nil {
+		return params, fmt.Errorf("invalid ledger params: %w", err)
+	}
+	return params, applyCommonFlags(cmd, &params.CommonParams)
+}
+
+func authParamsFromFlags(cmd *cobra.Command) (models.AuthParams, error) {
+	var params models.AuthParams
+	if err := readJSONArg(mustString(cmd, "params"), &params); err != nil {
+		return params, fmt.Errorf("invalid --params: %w", err)
+	}
+	f := cmd.Flags()
+	if f.Changed("api-key") {
+		params.APIKey, _ = f.GetString("api-key")
+	}
+	if f.Changed("client-id") {
+		params.ClientID, _ = f.GetString("client-id")
+	}
+	if f.Changed("client-secret") {
+		params.ClientSecret, _ = f.GetString("client-secret")
+	}
+	data, err := keyValues(cmd, "data")
+	if err != nil {
+		return params, err
+	}
+	if len(data) > 0 {
+		if params.Data == nil {
+			params.Data = &map[string]string{}
+		}
+		for k, v := range data {
+			(*params.Data)[k] = v
+		}
+	}
+	return params, nil
+}
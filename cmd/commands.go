@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/nikhiljohn10/uagplugin/host"
 	"github.com/nikhiljohn10/uagplugin/internal/version"
 	"github.com/nikhiljohn10/uagplugin/logger"
 	"github.com/nikhiljohn10/uagplugin/typing"
@@ -8,7 +9,7 @@ import (
 )
 
 var Root = &cobra.Command{
-	Use:   "uagplugin [.so file]",
+	Use:   "uagplugin [plugin file]",
 	Short: "UAG Plugin Tool is a cli application to manage plugins",
 	Long: `UAG Plugin Tool is a CLI application used to manage plugins that includes installing, testing and updating plugins.
 This application provides various commands to interact with your system.`,
@@ -19,7 +20,7 @@ This application provides various commands to interact with your system.`,
 			return
 		}
 
-		// If an argument is provided, show metadata of the .so file provided
+		// If an argument is provided, show metadata of the plugin file provided
 		showMetadata(args[0])
 	},
 }
@@ -33,9 +34,20 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+var initCmd = &cobra.Command{
+	Use:   "init [plugin name]",
+	Short: "Create a new plugin from a template",
+	Long: `Generate go.mod, plugin.go (exporting Plugin with Contacts and Ledger
+stubs), a conformance test using testkit and, for --auth or the auth
+template, an Authenticator stub. Templates: file (embedded CSV data),
+api (HTTP backend) and auth (HTTP backend with API key authentication).`,
+	Args: cobra.ExactArgs(1),
+	Run:  pluginInit,
+}
+
 var pluginInstallCmd = &cobra.Command{
-	Use:   "install [repository url]",
-	Short: "Install a plugin from a GitHub repository",
+	Use:   "install [repository url][@version|range|branch|commit]",
+	Short: "Install a plugin from a git repository (any host, ssh://, file:// or a local path)",
 	Args:  cobra.ExactArgs(1),
 	Run:   pluginInstall,
 }
@@ -47,29 +59,238 @@ var pluginInstallDirCmd = &cobra.Command{
 	Run:   pluginInstallDir,
 }
 
+var updateCmd = &cobra.Command{
+	Use:   "update [plugin name]",
+	Short: "Upgrade installed repository plugins to newer tags",
+	Long: `Fetch tags for installed repository plugins and rebuild them at the newest
+semver tag within the installed major version (or any newer tag with --major).
+The new build is smoke tested and the previous plugin is restored if it fails.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  pluginUpdate,
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Install the plugins declared in uag.yaml/uag.json and pin them in uag.lock",
+	Long: `Make ~/.uag/plugins match the project manifest. Plugins pinned in uag.lock are
+rebuilt at their locked commit and must reproduce the locked checksum; new or
+changed declarations are resolved and written to uag.lock.`,
+	Args: cobra.NoArgs,
+	Run:  pluginSync,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Args:  cobra.NoArgs,
+	Run:   pluginList,
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info [plugin name]",
+	Short: "Show where an installed plugin came from and how it was built",
+	Args:  cobra.ExactArgs(1),
+	Run:   pluginInfo,
+}
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall [plugin name]",
+	Short: "Remove an installed plugin and its cloned source",
+	Args:  cobra.ExactArgs(1),
+	Run:   pluginUninstall,
+}
+
 var testCmd = &cobra.Command{
-	Use:   "test [plugin .so file or directory]",
-	Short: "Test installed plugins (.so) and optionally source tests",
-	Long:  "Run smoke tests against compiled plugins in .uag/plugins/build and optionally run 'go test' in source directories.",
+	Use:   "test [plugin file, directory or grpc://host:port/name]",
+	Short: "Test installed plugins (.so or .rpc) and optionally source tests",
+	Long:  "Run smoke tests against compiled plugins in .uag/plugins/build, or a plugin served with 'uagplugin serve --grpc', and optionally run 'go test' in source directories.",
 	Args:  cobra.MaximumNArgs(1),
 	Run:   testPlugins,
 }
 
+var callCmd = &cobra.Command{
+	Use:   "call [plugin name or file] [meta|health|auth|contacts|ledger]",
+	Short: "Invoke a single plugin method and print the result",
+	Long: `Load an installed plugin (by name) or a compiled plugin file and call one
+method. Parameters come from --params (inline JSON or @file.json) and the
+method's flags, which override values from --params. With --all-pages,
+contacts and ledger follow next_cursor until the last page.`,
+	Args: cobra.ExactArgs(2),
+	Run:  pluginCall,
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth [plugin name or file]",
+	Short: "Authorize an OAuth2 plugin and store its tokens",
+	Long: `Run the OAuth2 authorization code flow with PKCE for a plugin whose metadata
+declares auth_type oauth2. A loopback server on 127.0.0.1 receives the redirect;
+the tokens are stored in ~/.uag/plugins/tokens and refreshed automatically
+before 'call' and 'test' use them.`,
+	Args: cobra.ExactArgs(1),
+	Run:  pluginAuth,
+}
+
+var credsCmd = &cobra.Command{
+	Use:   "creds",
+	Short: "Manage encrypted plugin credentials",
+	Long: `Store AuthCredentials per plugin and named profile in ~/.uag/credentials,
+encrypted with AES-256-GCM under a key derived from a passphrase with scrypt.
+The passphrase comes from UAG_CREDENTIALS_PASSPHRASE, the output of
+UAG_CREDENTIALS_PASSPHRASE_COMMAND (e.g. a keyring lookup) or a prompt.
+Use a stored profile with 'call --profile' and 'test --profile'.`,
+}
+
+var credsSetCmd = &cobra.Command{
+	Use:   "set [plugin name] [profile]",
+	Short: "Store credentials for a plugin profile (default profile: default)",
+	Long: `Store credentials for a plugin profile. Values are read from --file (a JSON
+object, or - for stdin) or prompted for without echo: the --key names, or the
+api_credentials declared by the installed plugin.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  pluginCredsSet,
+}
+
+var credsGetCmd = &cobra.Command{
+	Use:   "get [plugin name] [profile]",
+	Short: "Print the stored credentials of a plugin profile as JSON",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   pluginCredsGet,
+}
+
+var credsListCmd = &cobra.Command{
+	Use:   "list [plugin name]",
+	Short: "List stored credential profiles",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   pluginCredsList,
+}
+
+var credsRmCmd = &cobra.Command{
+	Use:   "rm [plugin name] [profile]",
+	Short: "Remove the stored credentials of a plugin profile",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   pluginCredsRemove,
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [plugin name...]",
+	Short: "Serve installed plugins over a REST or gRPC API",
+	Long: `Load the installed plugins (or only those named) and expose them over HTTP:
+GET /plugins, GET /plugins/{id}/meta, GET /plugins/{id}/health,
+POST /plugins/{id}/auth, GET /plugins/{id}/contacts and GET /plugins/{id}/ledger.
+Query parameters use the JSON field names of ContactQueryParams and
+LedgerQueryParams. The OpenAPI document is served at /openapi.json.
+
+With --grpc, serve the uagplugin.v2.PluginService defined in
+proto/uagplugin/v2/plugin.proto instead (default address 127.0.0.1:9090).
+Other hosts use the plugins as grpc://host:port/name, e.g.
+'uagplugin test grpc://127.0.0.1:9090/crm'.`,
+	Run: pluginServe,
+}
+
+const tokenFlagUsage = "Access token for private HTTPS repositories, used if anonymous access fails (defaults to GITHUB_TOKEN for github.com)"
+
 func init() {
 	Root.AddCommand(versionCmd)
 	Root.Version = version.Version
 
+	initCmd.Flags().String("template", "file", "Plugin template: file|api|auth")
+	initCmd.Flags().Bool("auth", false, "Add an Authenticator stub")
+	initCmd.Flags().String("dir", "", "Output directory (default: ./<plugin name>)")
+	initCmd.Flags().String("module", "", "Go module path (default: the plugin name)")
+	Root.AddCommand(initCmd)
+
 	pluginInstallDirCmd.Flags().String("name", "", "Name of the plugin")
+	pluginInstallDirCmd.Flags().String("runtime", "native", "Plugin runtime: native (.so, in-process) | rpc (executable over stdio)")
 	pluginInstallCmd.AddCommand(pluginInstallDirCmd)
 
-	pluginInstallCmd.Flags().String("token", "", "GitHub Personal Access Token for cloning private repositories")
+	pluginInstallCmd.Flags().String("name", "", "Name of the plugin (default: repository name)")
+	pluginInstallCmd.Flags().Bool("pre", false, "Allow pre-release tags (e.g. v1.3.0-rc.1) when resolving the version")
+	pluginInstallCmd.Flags().String("token", "", tokenFlagUsage)
+	pluginInstallCmd.Flags().String("runtime", "native", "Plugin runtime: native (.so, in-process) | rpc (executable over stdio)")
 	Root.AddCommand(pluginInstallCmd)
 
+	updateCmd.Flags().Bool("all", false, "Update every plugin installed from a repository")
+	updateCmd.Flags().Bool("major", false, "Allow updates across major versions")
+	updateCmd.Flags().Bool("check", false, "Only report available updates")
+	updateCmd.Flags().Bool("pre", false, "Allow updating to pre-release tags")
+	updateCmd.Flags().String("token", "", tokenFlagUsage)
+	updateCmd.Flags().Int("timeout", 5, "Per-call timeout in seconds for the post-update smoke test")
+	Root.AddCommand(updateCmd)
+
+	syncCmd.Flags().String("file", "", "Manifest path (default: uag.yaml, uag.yml or uag.json in the current directory)")
+	syncCmd.Flags().Bool("update", false, "Ignore uag.lock and re-resolve every plugin")
+	syncCmd.Flags().Bool("prune", true, "Uninstall plugins that are not declared in the manifest")
+	syncCmd.Flags().String("token", "", tokenFlagUsage)
+	Root.AddCommand(syncCmd)
+
+	listCmd.Flags().Bool("json", false, "Output JSON")
+	Root.AddCommand(listCmd)
+
+	infoCmd.Flags().Bool("json", false, "Output JSON")
+	Root.AddCommand(infoCmd)
+
+	Root.AddCommand(uninstallCmd)
+
+	callCmd.Flags().StringP("output", "o", "json", "Output format: json|table|csv")
+	callCmd.Flags().String("params", "", "Method parameters as JSON or @file.json (ContactQueryParams, LedgerQueryParams or AuthParams)")
+	callCmd.Flags().String("auth", "", "AuthCredentials as JSON or @file.json")
+	callCmd.Flags().String("profile", "", "Stored credential profile (see 'uagplugin creds'); --auth values take precedence")
+	callCmd.Flags().Int("timeout", 30, "Timeout in seconds for the whole call (0 for none)")
+	callCmd.Flags().Bool("all-pages", false, "Follow next_cursor and merge every page (contacts, ledger)")
+	callCmd.Flags().Int("max-pages", host.DefaultMaxPages, "Stop --all-pages with an error after this many pages")
+	callCmd.Flags().String("cursor", "", "Pagination cursor")
+	callCmd.Flags().Int("limit", 0, "Page size")
+	callCmd.Flags().Int("page", 0, "Page number (takes priority over --cursor)")
+	callCmd.Flags().Bool("desc", false, "Sort descending")
+	callCmd.Flags().StringArray("extra", nil, "Extra parameter as key=value (repeatable)")
+	callCmd.Flags().String("search", "", "contacts: search text")
+	callCmd.Flags().StringSlice("ids", nil, "contacts: comma-separated contact IDs")
+	callCmd.Flags().String("customer", "", "ledger: customer ID")
+	callCmd.Flags().String("start", "", "ledger: start date (YYYY-MM-DD)")
+	callCmd.Flags().String("end", "", "ledger: end date (YYYY-MM-DD)")
+	callCmd.Flags().StringSlice("doc-type", nil, "ledger: comma-separated document types")
+	callCmd.Flags().String("api-key", "", "auth: API key")
+	callCmd.Flags().String("client-id", "", "auth: client ID")
+	callCmd.Flags().String("client-secret", "", "auth: client secret")
+	callCmd.Flags().StringArray("data", nil, "auth: extra data as key=value (repeatable)")
+	Root.AddCommand(callCmd)
+
+	authCmd.Flags().String("client-id", "", "OAuth2 client ID (default: the client_id in the plugin metadata)")
+	authCmd.Flags().String("client-secret", "", "OAuth2 client secret, for confidential clients")
+	authCmd.Flags().StringSlice("scopes", nil, "Scopes to request (default: the scopes in the plugin metadata)")
+	authCmd.Flags().Int("port", 0, "Port of the loopback redirect server, http://127.0.0.1:<port>/callback (default: any free port)")
+	authCmd.Flags().Bool("no-browser", false, "Only print the authorization URL")
+	authCmd.Flags().Int("timeout", 300, "Seconds to wait for the authorization to complete")
+	authCmd.Flags().Bool("refresh", false, "Refresh the stored token now instead of authorizing again")
+	authCmd.Flags().Bool("logout", false, "Remove the stored token")
+	Root.AddCommand(authCmd)
+
+	serveCmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on (default 127.0.0.1:9090 with --grpc)")
+	serveCmd.Flags().Bool("grpc", false, "Serve gRPC instead of REST")
+	serveCmd.Flags().Int("timeout", 30, "Per-request timeout in seconds for plugin calls")
+	serveCmd.Flags().String("profile", "", "Stored credential profile added to contacts and ledger requests (see 'uagplugin creds')")
+	serveCmd.Flags().Bool("openapi", false, "Print the OpenAPI document and exit")
+	Root.AddCommand(serveCmd)
+
+	credsSetCmd.Flags().StringSlice("key", nil, "Credential keys to prompt for (default: the plugin's api_credentials)")
+	credsSetCmd.Flags().String("file", "", "Read the credentials from a JSON object file (- for stdin)")
+	credsSetCmd.Flags().Bool("merge", false, "Keep stored keys that are not given again")
+	credsGetCmd.Flags().String("key", "", "Print only the value of this key")
+	credsListCmd.Flags().Bool("json", false, "Output JSON")
+	credsCmd.AddCommand(credsSetCmd, credsGetCmd, credsListCmd, credsRmCmd)
+	Root.AddCommand(credsCmd)
+
 	testCmd.Flags().Int("timeout", 5, "Per-call timeout in seconds")
 	testCmd.Flags().String("env-file", "", "Optional .env file to load before testing")
-	testCmd.Flags().String("auth", "", "JSON object for AuthCredentials passed to plugin functions")
-	testCmd.Flags().String("params", "", "JSON object for Params passed to plugin functions")
-	testCmd.Flags().String("mode", "smoke", "Test mode: smoke|source|all")
+	testCmd.Flags().String("auth", "", "AuthCredentials passed to plugin functions, as JSON or @file.json")
+	testCmd.Flags().String("profile", "", "Stored credential profile for each plugin (see 'uagplugin creds'); --auth and scenario values take precedence")
+	testCmd.Flags().String("auth-params", "", "AuthParams for plugins implementing Auth, as JSON or @file.json")
+	testCmd.Flags().String("contact-params", "", "ContactQueryParams for Contacts, as JSON or @file.json")
+	testCmd.Flags().String("ledger-params", "", "LedgerQueryParams for Ledger, as JSON or @file.json")
+	testCmd.Flags().String("scenarios", "", "Scenario file to use instead of each plugin's uagtest.yaml")
+	testCmd.Flags().String("params", "", "JSON applied to both contact and ledger params")
+	_ = testCmd.Flags().MarkDeprecated("params", "use --contact-params and --ledger-params")
+	testCmd.Flags().String("mode", "smoke", "Test mode: smoke|source|all|conformance")
 	testCmd.Flags().Bool("json", false, "Output JSON report")
 	Root.AddCommand(testCmd)
 }
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/credstore"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Environment variables supplying the credential store passphrase. The
+// command form lets a system keyring hold it, e.g.
+// "secret-tool lookup service uagplugin" or
+// "security find-generic-password -w -s uagplugin".
+const (
+	envCredsPassphrase        = "UAG_CREDENTIALS_PASSPHRASE"
+	envCredsPassphraseCommand = "UAG_CREDENTIALS_PASSPHRASE_COMMAND"
+)
+
+func pluginCredsSet(cmd *cobra.Command, args []string) {
+	if err := runCredsSet(cmd, args); err != nil {
+		logger.Error("%v", err)
+		os.Exit(1)
+	}
+}
+
+func runCredsSet(cmd *cobra.Command, args []string) error {
+	plugin, profile := credsTarget(args)
+	if err := credstore.ValidateName("plugin", plugin); err != nil {
+		return err
+	}
+	if err := credstore.ValidateName("profile", profile); err != nil {
+		return err
+	}
+	store, err := credStore()
+	if err != nil {
+		return err
+	}
+
+	creds := models.AuthCredentials{}
+	if merge, _ := cmd.Flags().GetBool("merge"); merge {
+		old, err := store.Get(plugin, profile)
+		if err != nil && !errors.Is(err, credstore.ErrNotFound) {
+			return err
+		}
+		maps.Copy(creds, old)
+	}
+
+	// The plugin's metadata, if it is installed, supplies the keys to prompt
+	// for and is used to check the result.
+	var meta *models.MetaData
+	if path, err := resolvePluginFile(plugin); err == nil {
+		if meta, err = pluginMeta(cmd.Context(), path); err != nil {
+			logger.Debug("Could not read metadata of %s: %v", plugin, err)
+		}
+	}
+
+	keys, _ := cmd.Flags().GetStringSlice("key")
+	src := mustString(cmd, "file")
+	switch {
+	case src != "":
+		var data []byte
+		if src == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(src)
+		}
+		if err != nil {
+			return err
+		}
+		var values models.AuthCredentials
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("invalid --file: %w", err)
+		}
+		maps.Copy(creds, values)
+	case len(keys) == 0 && meta != nil && meta.ApiCredentials != nil:
+		keys = slices.Clone(*meta.ApiCredentials)
+		fallthrough
+	default:
+		if len(keys) == 0 {
+			return fmt.Errorf("no credential keys to prompt for: use --key <name> or --file <path>")
+		}
+		for _, k := range keys {
+			v, err := readSecret(fmt.Sprintf("%s: ", k))
+			if err != nil {
+				return err
+			}
+			creds[k] = v
+		}
+	}
+	if len(creds) == 0 {
+		return fmt.Errorf("no credentials given")
+	}
+
+	if err := store.Set(plugin, profile, creds); err != nil {
+		return err
+	}
+	if err := host.ValidateAPICredentials(meta, &creds); err != nil {
+		logger.Warn("%v", err)
+	}
+	logger.Info("Stored credentials for %s (profile %s)", plugin, profile)
+	return nil
+}
+
+func pluginCredsGet(cmd *cobra.Command, args []string) {
+	plugin, profile := credsTarget(args)
+	store, err := credStore()
+	if err != nil {
+		logger.Fatal("%v", err)
+	}
+	creds, err := store.Get(plugin, profile)
+	if err != nil {
+		logger.Fatal("%v", err)
+	}
+	if key := mustString(cmd, "key"); key != "" {
+		v, ok := creds[key]
+		if !ok {
+			logger.Fatal("%s profile %q has no key %q", plugin, profile, key)
+		}
+		fmt.Println(v)
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(creds)
+}
+
+func pluginCredsList(cmd *cobra.Command, args []string) {
+	store, err := credStore()
+	if err != nil {
+		logger.Fatal("%v", err)
+	}
+	plugin := ""
+	if len(args) > 0 {
+		plugin = args[0]
+	}
+	entries, err := store.List(plugin)
+	if err != nil {
+		logger.Fatal("%v", err)
+	}
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		if entries == nil {
+			entries = []credstore.Entry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(entries)
+		return
+	}
+	if len(entries) == 0 {
+		logger.Info("No stored credentials.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PLUGIN\tPROFILE\tUPDATED")
+	for _, e := range entries {
+		updated := "-"
+		if !e.UpdatedAt.IsZero() {
+			updated = e.UpdatedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", e.Plugin, e.Profile, updated)
+	}
+	_ = w.Flush()
+}
+
+func pluginCredsRemove(cmd *cobra.Command, args []string) {
+	plugin, profile := credsTarget(args)
+	store, err := credStore()
+	if err != nil {
+		logger.Fatal("%v", err)
+	}
+	if err := store.Remove(plugin, profile); err != nil {
+		logger.Fatal("%v", err)
+	}
+	logger.Info("Removed credentials for %s (profile %s)", plugin, profile)
+}
+
+// credsTarget splits <plugin> [profile] arguments.
+func credsTarget(args []string) (plugin, profile string) {
+	profile = credstore.DefaultProfile
+	if len(args) > 1 {
+		profile = args[1]
+	}
+	return args[0], profile
+}
+
+// credStore opens the credential store in ~/.uag/credentials. The passphrase
+// is only requested once a profile is read or written.
+func credStore() (*credstore.Store, error) {
+	dir, err := credstore.Path()
+	if err != nil {
+		return nil, err
+	}
+	return encryptedStore(dir), nil
+}
+
+// encryptedStore returns a store in dir that takes its passphrase from the
+// environment or asks for it on the terminal.
+func encryptedStore(dir string) *credstore.Store {
+	s := &credstore.Store{Dir: dir}
+	s.Passphrase = func() ([]byte, error) {
+		if p := os.Getenv(envCredsPassphrase); p != "" {
+			return []byte(p), nil
+		}
+		if c := os.Getenv(envCredsPassphraseCommand); c != "" {
+			return passphraseFromCommand(c)
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, fmt.Errorf("no passphrase for the credential store: set %s or %s", envCredsPassphrase, envCredsPassphraseCommand)
+		}
+		existing, _ := s.List("")
+		pass, err := readSecret("Credential store passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) == 0 {
+			again, err := readSecret("Repeat passphrase: ")
+			if err != nil {
+				return nil, err
+			}
+			if again != pass {
+				return nil, fmt.Errorf("passphrases do not match")
+			}
+		}
+		return []byte(pass), nil
+	}
+	return s
+}
+
+// storedCredentials loads the --profile credentials for plugin from store and
+// merges auth over them; an empty profile returns auth unchanged.
+func storedCredentials(store *credstore.Store, profile, plugin string, auth models.AuthCredentials) (models.AuthCredentials, error) {
+	if profile == "" {
+		return auth, nil
+	}
+	creds, err := store.Get(plugin, profile)
+	if err != nil {
+		return auth, err
+	}
+	maps.Copy(creds, auth)
+	return creds, nil
+}
+
+func passphraseFromCommand(command string) ([]byte, error) {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", envCredsPassphraseCommand, err)
+	}
+	return bytes.TrimRight(out, "\r\n"), nil
+}
+
+// stdinLines is shared so piped values for several keys are not lost to
+// read-ahead buffering.
+var stdinLines = bufio.NewReader(os.Stdin)
+
+// readSecret prompts on stderr and reads a line from stdin, without echo
+// when stdin is a terminal.
+func readSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(b), err
+	}
+	line, err := stdinLines.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
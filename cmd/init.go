@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nikhiljohn10/uagplugin/internal/scaffold"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/internal/version"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/spf13/cobra"
+)
+
+func pluginInit(cmd *cobra.Command, args []string) {
+	name := args[0]
+	tmpl, _ := cmd.Flags().GetString("template")
+	withAuth, _ := cmd.Flags().GetBool("auth")
+	dir, _ := cmd.Flags().GetString("dir")
+	modulePath, _ := cmd.Flags().GetString("module")
+	if dir == "" {
+		dir = name
+	}
+	if modulePath == "" {
+		modulePath = name
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		logger.Error("Failed to resolve %s: %v", dir, err)
+		os.Exit(1)
+	}
+
+	files, err := scaffold.Generate(dir, scaffold.Options{Name: name, Template: tmpl, Auth: withAuth})
+	if err != nil {
+		logger.Error("%v", err)
+		os.Exit(1)
+	}
+	for _, f := range files {
+		logger.Info("Created %s", filepath.Join(dir, f))
+	}
+
+	if err := utils.InitPluginModule(cmd.Context(), dir, modulePath, version.Version); err != nil {
+		// The sources are in place; only dependency resolution is left.
+		logger.Warn("Failed to set up go.mod: %v", err)
+		logger.Warn("Require %s in %s and run 'go mod tidy'.", "github.com/nikhiljohn10/uagplugin", filepath.Join(dir, "go.mod"))
+	} else {
+		logger.Info("Created %s", filepath.Join(dir, "go.mod"))
+	}
+
+	fmt.Printf("\nNext steps:\n  cd %s\n  go test ./...\n  uagplugin install dir . --name %s\n", dir, name)
+}
@@ -2,102 +2,37 @@ package cmd
 
 import (
 	"context"
-	"fmt"
-	"net/url"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
-	"time"
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/gitsource"
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
 	"github.com/nikhiljohn10/uagplugin/internal/utils"
 	"github.com/nikhiljohn10/uagplugin/logger"
 	"github.com/spf13/cobra"
 )
 
-type semVer struct {
-	major, minor, patch int
-}
-
-func (s semVer) isGreaterThan(other semVer) bool {
-	if s.major != other.major {
-		return s.major > other.major
-	}
-	if s.minor != other.minor {
-		return s.minor > other.minor
-	}
-	return s.patch > other.patch
-}
-
-func parseVersion(v string) (semVer, error) {
-	v = strings.TrimPrefix(v, "v")
-	parts := strings.Split(v, ".")
-	if len(parts) != 3 {
-		return semVer{}, fmt.Errorf("invalid version format: %s", v)
-	}
-
-	major, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return semVer{}, fmt.Errorf("invalid major version: %s", parts[0])
-	}
-	minor, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return semVer{}, fmt.Errorf("invalid minor version: %s", parts[1])
-	}
-	patch, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return semVer{}, fmt.Errorf("invalid patch version: %s", parts[2])
-	}
-
-	return semVer{major, minor, patch}, nil
-}
-
-func checkInstallVersion(version string) error {
-	if version == "" {
-		return fmt.Errorf("version cannot be empty")
-	}
-	if version == "latest" {
-		return nil
-	}
-	_, err := parseVersion(version)
-	if err != nil {
-		return fmt.Errorf("invalid version format: %v", err)
-	}
-	return nil
-}
-
 func pluginInstall(cmd *cobra.Command, args []string) {
 	pluginName, _ := cmd.Flags().GetString("name")
 	token, _ := cmd.Flags().GetString("token")
-	repoUrl := args[0]
-	version := "latest"
-	if strings.Contains(args[0], "@") {
-		parts := strings.SplitN(args[0], "@", 2)
-		repoUrl = parts[0]
-		if len(parts) == 2 && parts[1] != "" {
-			version = strings.ToLower(parts[1])
-		}
-	}
-	if err := checkInstallVersion(version); err != nil {
-		logger.Error("Invalid version specified: %v", err)
-		return
-	}
-	if repoUrl == "" {
-		logger.Error("Repository URL cannot be empty.")
+	runtime, _ := cmd.Flags().GetString("runtime")
+	includePre, _ := cmd.Flags().GetBool("pre")
+	transport, err := host.TransportFromString(runtime)
+	if err != nil {
+		logger.Error("%v", err)
 		return
 	}
-	if !strings.HasPrefix(repoUrl, "http") {
-		repoUrl = "https://" + repoUrl
+	repoUrl, ref := gitsource.SplitRef(args[0])
+	if ref == "" {
+		ref = "latest"
 	}
-	u, err := url.ParseRequestURI(repoUrl)
-	if err != nil || u.Host == "" {
-		logger.Error("Invalid repository URL")
+	src, err := gitsource.Normalize(repoUrl)
+	if err != nil {
+		logger.Error("%v", err)
 		return
 	}
-	pluginRepoInstall(cmd.Context(), pluginName, token, u, version)
+	pluginRepoInstall(cmd.Context(), pluginName, token, src, ref, includePre, transport)
 }
 
 func pluginInstallDir(cmd *cobra.Command, args []string) {
@@ -111,6 +46,13 @@ func pluginInstallDir(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	runtime, _ := cmd.Flags().GetString("runtime")
+	transport, err := host.TransportFromString(runtime)
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+
 	srcDir := args[0]
 	if srcDir == "." || srcDir == "./" {
 		cwd, err := os.Getwd()
@@ -131,7 +73,7 @@ func pluginInstallDir(cmd *cobra.Command, args []string) {
 	}
 
 	// Get src absolute path
-	srcDir, err := filepath.Abs(srcDir)
+	srcDir, err = filepath.Abs(srcDir)
 	if err != nil {
 		logger.Error("Failed to resolve absolute path: %v", err)
 		return
@@ -166,18 +108,25 @@ func pluginInstallDir(cmd *cobra.Command, args []string) {
 	// 	return
 	// }
 
-	utils.BuildAndLog(cmd.Context(), pluginName, srcDir, "dir")
-}
-
-func pluginRepoInstall(ctx context.Context, pluginName, token string, u *url.URL, version string) {
-	if u.Host != "github.com" {
-		logger.Error("Only GitHub repositories are supported.")
+	artifact, err := utils.BuildAndLog(cmd.Context(), pluginName, srcDir, "dir", transport)
+	if err != nil {
 		return
 	}
+	entry := registry.Entry{
+		Name:       pluginName,
+		SourceType: registry.SourceDir,
+		Source:     srcDir,
+		Commit:     gitHeadCommit(srcDir),
+		Runtime:    transport,
+	}
+	if _, err := recordInstall(cmd.Context(), entry, artifact); err != nil {
+		logger.Error("%v", err)
+	}
+}
 
-	parts := strings.Split(u.Path, "/")
+func pluginRepoInstall(ctx context.Context, pluginName, token, src, ref string, includePre bool, transport host.Transport) {
 	if pluginName == "" {
-		pluginName = parts[len(parts)-1]
+		pluginName = gitsource.RepoName(src)
 	}
 	baseDir, err := utils.GetBaseDir()
 	if err != nil {
@@ -186,117 +135,42 @@ func pluginRepoInstall(ctx context.Context, pluginName, token string, u *url.URL
 	}
 	srcDir := filepath.Join(baseDir, "pkgs", pluginName)
 
-	if _, err := os.Stat(srcDir); err == nil {
-		if err := os.RemoveAll(srcDir); err != nil {
-			logger.Error("Failed to remove plugin directory: %v", err)
-		}
-	}
-
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
-	}
-
-	owner := parts[len(parts)-2]
-	apiURL := "https://api.github.com/repos/" + owner + "/" + pluginName
-	var cancel context.CancelFunc
-	if _, ok := ctx.Deadline(); !ok {
-		ctx, cancel = context.WithTimeout(ctx, 15*time.Second)
-		defer cancel()
-	}
-	isPublic := utils.IsRepoPublic(ctx, apiURL, token)
-
-	cloneOptions := &git.CloneOptions{
-		URL:      u.String(),
-		Progress: nil,
-	}
-	if !isPublic {
-		if token == "" {
-			logger.Warn("No token provided, cannot clone private repository.")
-			return
-		}
-		auth := &http.BasicAuth{Username: "access_token", Password: token}
-		cloneOptions.Auth = auth
-	}
-	repo, err := git.PlainClone(srcDir, false, cloneOptions)
+	repo, err := gitsource.Clone(ctx, srcDir, src, gitsource.Credentials{Token: token})
 	if err != nil {
 		logger.Error("Failed to clone plugin: %v", err)
 		return
 	}
 
-	tagRefs, err := repo.Tags()
+	res, err := gitsource.Resolve(repo, ref, includePre)
 	if err != nil {
-		logger.Error("Failed to get tags: %v", err)
+		logger.Error("Failed to resolve %s: %v", ref, err)
 		return
 	}
-
-	var requestedVersion semVer
-	wantsSpecificVersion := version != "latest"
-	if wantsSpecificVersion {
-		parsedVersion, err := parseVersion(version)
-		if err != nil {
-			logger.Error("Invalid version requested: %v", err)
-			return
-		}
-		requestedVersion = parsedVersion
+	if err := gitsource.Checkout(repo, res.Commit); err != nil {
+		logger.Error("Failed to checkout %s: %v", res, err)
+		return
 	}
+	logger.Info("Checked out %s", res)
 
-	var latestVersion semVer
-	var latestTag *plumbing.Reference
-	var isLatestVersionSet bool
-	var requestedTag *plumbing.Reference
+	entry := registry.Entry{
+		Name:       pluginName,
+		SourceType: registry.SourceRepo,
+		Source:     src,
+		Commit:     res.Commit.String(),
+		Runtime:    transport,
+	}
+	if res.Kind == gitsource.RefTag {
+		entry.Tag = res.Name
+	}
+	if ref != "latest" {
+		entry.Ref = ref
+	}
 
-	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
-		tagName := strings.TrimPrefix(ref.Name().String(), "refs/tags/")
-		v, err := parseVersion(tagName)
-		if err != nil {
-			return nil // Ignore invalid tags
-		}
-		if wantsSpecificVersion && requestedTag == nil {
-			if v == requestedVersion {
-				requestedTag = ref
-			}
-		}
-		if !isLatestVersionSet || v.isGreaterThan(latestVersion) {
-			latestVersion = v
-			latestTag = ref
-			isLatestVersionSet = true
-		}
-		return nil
-	})
+	artifact, err := utils.BuildAndLog(ctx, pluginName, srcDir, "repo", transport)
 	if err != nil {
-		logger.Error("Failed to iterate over tags: %v", err)
 		return
 	}
-
-	var targetTag *plumbing.Reference
-	var targetVersion semVer
-	if wantsSpecificVersion {
-		if requestedTag == nil {
-			logger.Error("Requested version v%d.%d.%d not found", requestedVersion.major, requestedVersion.minor, requestedVersion.patch)
-			return
-		}
-		targetTag = requestedTag
-		targetVersion = requestedVersion
-	} else {
-		targetTag = latestTag
-		targetVersion = latestVersion
-	}
-
-	if targetTag != nil {
-		w, err := repo.Worktree()
-		if err != nil {
-			logger.Error("Failed to get worktree: %v", err)
-			return
-		}
-		err = w.Checkout(&git.CheckoutOptions{
-			Hash: targetTag.Hash(),
-		})
-		if err != nil {
-			logger.Error("Failed to checkout tag: %v", err)
-			return
-		}
-		logger.Info("Checked out version: v%d.%d.%d", targetVersion.major, targetVersion.minor, targetVersion.patch)
+	if _, err := recordInstall(ctx, entry, artifact); err != nil {
+		logger.Error("%v", err)
 	}
-
-	utils.BuildAndLog(ctx, pluginName, srcDir, "repo")
 }
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+	"github.com/nikhiljohn10/uagplugin/internal/plugintest"
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/spf13/cobra"
+)
+
+// recordInstall fills in build details for a freshly built artifact and
+// stores the entry in the plugin registry.
+func recordInstall(ctx context.Context, entry registry.Entry, artifact string) (registry.Entry, error) {
+	entry.Artifact = artifact
+	entry.BuiltAt = time.Now().UTC()
+	entry.GoVersion = utils.GoVersion(ctx)
+	sum, err := registry.Checksum(artifact)
+	if err != nil {
+		return entry, fmt.Errorf("failed to checksum %s: %w", artifact, err)
+	}
+	entry.Checksum = sum
+	if meta, err := plugintest.GetPluginMetadata(artifact); err != nil {
+		logger.Warn("Could not read plugin metadata for registry: %v", err)
+	} else if meta != nil {
+		entry.ContractVersion = meta.ContractVersion
+		entry.PluginVersion = meta.Version
+	}
+
+	reg, err := registry.Load()
+	if err != nil {
+		return entry, err
+	}
+	// Reinstalling under another runtime replaces the previous artifact.
+	if prev, ok := reg.Get(entry.Name); ok && prev.Artifact != "" && prev.Artifact != artifact {
+		if err := buildinfo.Remove(prev.Artifact); err != nil {
+			logger.Warn("Failed to remove previous artifact %s: %v", prev.Artifact, err)
+		}
+	}
+	reg.Put(entry)
+	if err := reg.Save(); err != nil {
+		return entry, fmt.Errorf("failed to update plugin registry: %w", err)
+	}
+	return entry, nil
+}
+
+// gitHeadCommit returns the HEAD commit of the repository containing dir, if any.
+func gitHeadCommit(dir string) string {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
+
+func pluginList(cmd *cobra.Command, args []string) {
+	reg, err := registry.Load()
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+	entries := reg.List()
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(entries)
+		return
+	}
+	if len(entries) == 0 {
+		logger.Info("No plugins installed.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tRUNTIME\tCONTRACT\tSOURCE\tBUILT")
+	for _, e := range entries {
+		version := e.Tag
+		if version == "" {
+			version = e.PluginVersion
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Name, orDash(version), e.Runtime, orDash(e.ContractVersion), e.Source, e.BuiltAt.Local().Format(time.DateTime))
+	}
+	_ = w.Flush()
+}
+
+func pluginInfo(cmd *cobra.Command, args []string) {
+	reg, err := registry.Load()
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+	e, ok := reg.Get(args[0])
+	if !ok {
+		logger.Error("Plugin %q is not installed.", args[0])
+		return
+	}
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(e)
+		return
+	}
+	fmt.Printf("Name:        %s\n", e.Name)
+	fmt.Printf("Version:     %s\n", orDash(e.PluginVersion))
+	fmt.Printf("Source:      %s (%s)\n", e.Source, e.SourceType)
+	if e.Ref != "" {
+		fmt.Printf("Requested:   %s\n", e.Ref)
+	}
+	fmt.Printf("Tag:         %s\n", orDash(e.Tag))
+	fmt.Printf("Commit:      %s\n", orDash(e.Commit))
+	fmt.Printf("Runtime:     %s\n", e.Runtime)
+	fmt.Printf("Artifact:    %s\n", e.Artifact)
+	fmt.Printf("SHA-256:     %s\n", e.Checksum)
+	fmt.Printf("Built:       %s\n", e.BuiltAt.Local().Format(time.RFC3339))
+	fmt.Printf("Go:          %s\n", orDash(e.GoVersion))
+	fmt.Printf("Contract:    %s\n", orDash(e.ContractVersion))
+	if info, err := buildinfo.Read(e.Artifact); err == nil {
+		for _, m := range info.Replaces() {
+			fmt.Printf("Replace:     %s\n", m)
+		}
+	}
+}
+
+func pluginUninstall(cmd *cobra.Command, args []string) {
+	name := args[0]
+	reg, err := registry.Load()
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+	buildDir, err := utils.GetBuildDir()
+	if err != nil {
+		logger.Error("Failed to resolve plugin directories: %v", err)
+		return
+	}
+
+	var artifacts []string
+	e, ok := reg.Get(name)
+	if !ok {
+		// Not recorded (installed before the registry existed): remove loose artifacts.
+		for _, t := range []host.Transport{host.TransportNative, host.TransportRPC} {
+			p := filepath.Join(buildDir, name+t.Suffix())
+			if _, err := os.Stat(p); err == nil {
+				artifacts = append(artifacts, p)
+			}
+		}
+		if len(artifacts) == 0 {
+			logger.Error("Plugin %q is not installed.", name)
+			return
+		}
+		logger.Warn("Plugin %q is not in the registry; removing its build artifacts only.", name)
+	}
+
+	if ok {
+		if err := removeInstalled(reg, e); err != nil {
+			logger.Error("%v", err)
+			return
+		}
+		if err := reg.Save(); err != nil {
+			logger.Error("Failed to update plugin registry: %v", err)
+			return
+		}
+	} else {
+		for _, a := range artifacts {
+			if err := buildinfo.Remove(a); err != nil {
+				logger.Error("Failed to remove %s: %v", a, err)
+				return
+			}
+		}
+	}
+	logger.Info("Uninstalled plugin %s", name)
+}
+
+// removeInstalled deletes a registered plugin's artifact and, for repo
+// installs, its clone, then drops it from reg. The caller saves reg.
+func removeInstalled(reg *registry.Registry, e registry.Entry) error {
+	if err := buildinfo.Remove(e.Artifact); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", e.Artifact, err)
+	}
+	// Only cloned sources are owned by the CLI; never delete a user's local directory.
+	if e.SourceType == registry.SourceRepo {
+		baseDir, err := utils.GetBaseDir()
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(baseDir, "pkgs", e.Name)); err != nil {
+			logger.Warn("Failed to remove plugin source: %v", err)
+		}
+	}
+	reg.Remove(e.Name)
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/credstore"
+	"github.com/nikhiljohn10/uagplugin/internal/gateway"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout bounds how long in-flight requests may finish after a signal.
+const shutdownTimeout = 10 * time.Second
+
+func pluginServe(cmd *cobra.Command, args []string) {
+	if err := runServe(cmd, args); err != nil {
+		logger.Error("%v", err)
+		os.Exit(1)
+	}
+}
+
+func runServe(cmd *cobra.Command, names []string) error {
+	if openapi, _ := cmd.Flags().GetBool("openapi"); openapi {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(gateway.OpenAPI())
+	}
+
+	// Plugin processes must outlive the signal so in-flight requests can
+	// finish; they are closed after the server has shut down.
+	plugins, metas, err := loadServedPlugins(context.WithoutCancel(cmd.Context()), names)
+	defer func() {
+		for _, p := range plugins {
+			_ = p.Close()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	// One store serves every request, unlocked before requests share it so
+	// the passphrase is asked for once.
+	var creds *credstore.Store
+	profile := mustString(cmd, "profile")
+	if profile != "" {
+		if creds, err = credStore(); err != nil {
+			return err
+		}
+		if err := creds.Unlock(); err != nil {
+			return err
+		}
+	}
+	tokens, err := tokenStore()
+	if err != nil {
+		return err
+	}
+	if err := tokens.unlock(metas); err != nil {
+		return err
+	}
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+	timeout := time.Duration(timeoutSec) * time.Second
+	credentials := func(ctx context.Context, id string, _ *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error) {
+		auth, err := storedCredentials(creds, profile, id, auth)
+		if err != nil {
+			return auth, err
+		}
+		return withOAuth2Token(ctx, tokens, metas[id], id, auth)
+	}
+
+	addr := mustString(cmd, "addr")
+	useGRPC, _ := cmd.Flags().GetBool("grpc")
+	if useGRPC && !cmd.Flags().Changed("addr") {
+		addr = plugingrpc.DefaultAddr
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if useGRPC {
+		srv := &plugingrpc.Server{Plugins: plugins, Timeout: timeout, Credentials: credentials}
+		return serveGRPC(cmd.Context(), srv, ln)
+	}
+	srv := &gateway.Server{Plugins: plugins, Timeout: timeout, Credentials: credentials}
+	hs := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	errc := make(chan error, 1)
+	go func() { errc <- hs.Serve(ln) }()
+	logger.Info("Serving %d plugin(s) on http://%s (OpenAPI at /openapi.json)", len(plugins), ln.Addr())
+
+	select {
+	case err := <-errc:
+		return err
+	case <-cmd.Context().Done():
+	}
+	logger.Info("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := hs.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// serveGRPC serves srv on ln until ctx is done, then lets in-flight calls
+// finish for up to shutdownTimeout.
+func serveGRPC(ctx context.Context, srv *plugingrpc.Server, ln net.Listener) error {
+	gs := grpc.NewServer()
+	srv.Register(gs)
+	errc := make(chan error, 1)
+	go func() { errc <- gs.Serve(ln) }()
+	logger.Info("Serving %d plugin(s) over gRPC on %s (targets grpc://%s/<name>)", len(srv.Plugins), ln.Addr(), ln.Addr())
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	logger.Info("Shutting down...")
+	stopped := make(chan struct{})
+	go func() {
+		gs.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		gs.Stop()
+		return fmt.Errorf("shutdown: in-flight calls did not finish within %s", shutdownTimeout)
+	}
+	return <-errc
+}
+
+// loadServedPlugins opens the installed plugins, or only those named, keyed
+// by plugin name. Plugins that fail to load are skipped with a warning unless
+// they were named.
+func loadServedPlugins(ctx context.Context, names []string) (map[string]*host.Plugin, map[string]*models.MetaData, error) {
+	plugins := map[string]*host.Plugin{}
+	metas := map[string]*models.MetaData{}
+	var paths []string
+	if len(names) > 0 {
+		for _, name := range names {
+			path, err := resolvePluginFile(name)
+			if err != nil {
+				return plugins, metas, err
+			}
+			paths = append(paths, path)
+		}
+	} else {
+		buildDir, err := utils.GetBuildDir()
+		if err != nil {
+			return plugins, metas, err
+		}
+		for _, suffix := range []string{host.NativeSuffix, host.RPCSuffix} {
+			matches, _ := filepath.Glob(filepath.Join(buildDir, "*"+suffix))
+			paths = append(paths, matches...)
+		}
+		slices.Sort(paths)
+	}
+
+	for _, path := range paths {
+		name := host.PluginName(path)
+		if _, dup := plugins[name]; dup {
+			logger.Warn("Skipping %s: a plugin named %s is already loaded", path, name)
+			continue
+		}
+		p, meta, err := openWithMeta(ctx, path)
+		if err != nil {
+			if len(names) > 0 {
+				return plugins, metas, fmt.Errorf("%s: %w", name, err)
+			}
+			logger.Warn("Skipping %s: %v", name, err)
+			continue
+		}
+		plugins[name] = p
+		metas[name] = meta
+		logger.Debug("Loaded %s (%s)", name, p.Transport)
+	}
+	if len(plugins) == 0 {
+		return plugins, metas, fmt.Errorf("no plugins to serve; install one with 'uagplugin install'")
+	}
+	return plugins, metas, nil
+}
+
+// openWithMeta opens a plugin and reads its metadata, closing the plugin
+// again when its Meta call fails.
+func openWithMeta(ctx context.Context, path string) (*host.Plugin, *models.MetaData, error) {
+	p, err := host.Open(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := p.MetaContext(ctx)
+	if err != nil {
+		_ = p.Close()
+		return nil, nil, fmt.Errorf("meta: %w", err)
+	}
+	return p, meta, nil
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+	"github.com/nikhiljohn10/uagplugin/internal/gitsource"
+	"github.com/nikhiljohn10/uagplugin/internal/manifest"
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/spf13/cobra"
+)
+
+// syncOptions holds the flags of the sync command.
+type syncOptions struct {
+	token  string
+	update bool
+	prune  bool
+}
+
+func pluginSync(cmd *cobra.Command, args []string) {
+	opts := syncOptions{}
+	opts.token, _ = cmd.Flags().GetString("token")
+	opts.update, _ = cmd.Flags().GetBool("update")
+	opts.prune, _ = cmd.Flags().GetBool("prune")
+	path, _ := cmd.Flags().GetString("file")
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			logger.Error("Failed to get current working directory: %v", err)
+			return
+		}
+		if path, err = manifest.Find(cwd); err != nil {
+			logger.Error("%v", err)
+			return
+		}
+	}
+	m, err := manifest.Load(path)
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+	lock, err := manifest.LoadLock(m.LockPath())
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+
+	ctx := cmd.Context()
+	next := &manifest.Lock{}
+	failed := false
+	for _, p := range m.Plugins {
+		if ctx.Err() != nil {
+			return
+		}
+		var pinned *manifest.LockedPlugin
+		if l, ok := lock.Get(p.Name); ok && !opts.update {
+			pinned = &l
+		}
+		locked, err := syncPlugin(ctx, m, p, pinned, opts)
+		if err != nil {
+			logger.Error("%s: %v", p.Name, err)
+			failed = true
+			continue
+		}
+		next.Plugins = append(next.Plugins, locked)
+	}
+	if failed {
+		logger.Error("Sync failed; %s was not updated.", manifest.LockFileName)
+		os.Exit(1)
+	}
+
+	if opts.prune {
+		pruneUnlisted(m)
+	}
+	if err := next.Save(m.LockPath()); err != nil {
+		logger.Error("Failed to write %s: %v", m.LockPath(), err)
+		os.Exit(1)
+	}
+	logger.Info("Plugins match %s", m.LockPath())
+}
+
+// syncPlugin brings one manifest plugin in line with its lock entry, or
+// resolves a new one when the plugin is unpinned or its declaration changed.
+func syncPlugin(ctx context.Context, m *manifest.Manifest, p manifest.Plugin, pinned *manifest.LockedPlugin, opts syncOptions) (manifest.LockedPlugin, error) {
+	// The lock records dir sources as declared (relative to the manifest) so
+	// it can be shared; the registry records the resolved path.
+	want := manifest.LockedPlugin{Name: p.Name, Runtime: p.Runtime}
+	var source string
+	if p.Repo != "" {
+		src, err := gitsource.Normalize(p.Repo)
+		if err != nil {
+			return want, err
+		}
+		source = src
+		want.SourceType, want.Source, want.Constraint = registry.SourceRepo, source, p.Version
+	} else {
+		source = m.DirPath(p)
+		want.SourceType, want.Source = registry.SourceDir, p.Dir
+	}
+	// A lock entry only applies while the declaration it was made from is unchanged.
+	if pinned != nil && (pinned.SourceType != want.SourceType || pinned.Source != want.Source ||
+		pinned.Constraint != want.Constraint || pinned.Runtime != want.Runtime) {
+		logger.Info("%s: declaration changed, re-resolving", p.Name)
+		pinned = nil
+	}
+
+	if pinned != nil && installedMatches(*pinned, source) {
+		logger.Info("%s: up to date (%s)", p.Name, orDash(pinned.Tag))
+		return *pinned, nil
+	}
+
+	baseDir, buildDir, err := utils.GetBaseAndBuildDir()
+	if err != nil {
+		return want, err
+	}
+	srcDir := source
+	if want.SourceType == registry.SourceRepo {
+		srcDir = filepath.Join(baseDir, "pkgs", p.Name)
+		repo, err := ensureClone(ctx, srcDir, source, opts.token)
+		if err != nil {
+			return want, err
+		}
+		if pinned != nil {
+			want.Tag, want.Commit = pinned.Tag, pinned.Commit
+		} else {
+			res, err := gitsource.Resolve(repo, p.Version, false)
+			if err != nil {
+				return want, err
+			}
+			if res.Kind == gitsource.RefTag {
+				want.Tag = res.Name
+			}
+			want.Commit = res.Commit.String()
+		}
+		if err := gitsource.Checkout(repo, plumbing.NewHash(want.Commit)); err != nil {
+			return want, fmt.Errorf("failed to checkout %s: %w", want.Commit, err)
+		}
+	} else {
+		want.Commit = gitHeadCommit(srcDir)
+	}
+
+	// Build into a staging directory so a checksum mismatch leaves the
+	// installed plugin untouched.
+	staging, err := os.MkdirTemp(baseDir, "staging-")
+	if err != nil {
+		return want, err
+	}
+	defer os.RemoveAll(staging)
+	built, err := utils.BuildPlugin(ctx, p.Name, srcDir, staging, p.Runtime)
+	if err != nil {
+		return want, fmt.Errorf("build failed: %w", err)
+	}
+	if want.Checksum, err = registry.Checksum(built); err != nil {
+		return want, err
+	}
+	if pinned != nil && pinned.Checksum != want.Checksum {
+		if reproducible(built) {
+			return want, fmt.Errorf("checksum mismatch: %s pins %s, build produced %s (run 'uagplugin sync --update' to re-pin)", manifest.LockFileName, pinned.Checksum, want.Checksum)
+		}
+		// Without -trimpath the artifact embeds local paths, so a checksum
+		// pinned on another machine cannot be reproduced.
+		logger.Warn("%s: built without -trimpath, so the checksum pinned in %s is not verified", p.Name, manifest.LockFileName)
+	}
+	if err := os.MkdirAll(buildDir, 0755); err != nil {
+		return want, err
+	}
+	artifact := filepath.Join(buildDir, filepath.Base(built))
+	if err := buildinfo.Rename(built, artifact); err != nil {
+		return want, err
+	}
+
+	entry := registry.Entry{
+		Name:       p.Name,
+		SourceType: want.SourceType,
+		Source:     source,
+		Tag:        want.Tag,
+		Commit:     want.Commit,
+		Runtime:    p.Runtime,
+	}
+	if _, err := recordInstall(ctx, entry, artifact); err != nil {
+		return want, err
+	}
+	logger.Info("%s: installed %s", p.Name, orDash(want.Tag))
+	return want, nil
+}
+
+// reproducible reports whether artifact was built with -trimpath, so its
+// checksum does not depend on where it was built. RPC executables always
+// are; native plugins follow the host (see utils.BuildPlugin).
+func reproducible(artifact string) bool {
+	info, err := buildinfo.Read(artifact)
+	return err == nil && info.Trimpath
+}
+
+// installedMatches reports whether the registry already holds the pinned build
+// of source and its artifact on disk still has the pinned checksum.
+func installedMatches(l manifest.LockedPlugin, source string) bool {
+	reg, err := registry.Load()
+	if err != nil {
+		return false
+	}
+	e, ok := reg.Get(l.Name)
+	if !ok || e.Source != source || e.Commit != l.Commit || e.Runtime != l.Runtime || e.Checksum != l.Checksum {
+		return false
+	}
+	sum, err := registry.Checksum(e.Artifact)
+	return err == nil && sum == l.Checksum
+}
+
+// ensureClone returns the clone of src at dir, fetching new tags into an
+// existing clone or cloning afresh when dir is missing or points elsewhere.
+func ensureClone(ctx context.Context, dir, src, token string) (*git.Repository, error) {
+	creds := gitsource.Credentials{Token: token}
+	if repo, err := git.PlainOpen(dir); err == nil && gitsource.OriginURL(repo) == src {
+		if err := gitsource.FetchTags(ctx, repo, creds); err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+		return repo, nil
+	}
+	repo, err := gitsource.Clone(ctx, dir, src, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone plugin: %w", err)
+	}
+	return repo, nil
+}
+
+// pruneUnlisted uninstalls registry plugins the manifest does not declare.
+func pruneUnlisted(m *manifest.Manifest) {
+	reg, err := registry.Load()
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+	declared := map[string]bool{}
+	for _, p := range m.Plugins {
+		declared[p.Name] = true
+	}
+	for _, e := range reg.List() {
+		if declared[e.Name] {
+			continue
+		}
+		logger.Info("%s: not in manifest, uninstalling", e.Name)
+		if err := removeInstalled(reg, e); err != nil {
+			logger.Error("%s: %v", e.Name, err)
+		}
+	}
+	if err := reg.Save(); err != nil {
+		logger.Error("Failed to update plugin registry: %v", err)
+	}
+}
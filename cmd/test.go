@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/credstore"
 	"github.com/nikhiljohn10/uagplugin/internal/plugintest"
 	"github.com/nikhiljohn10/uagplugin/internal/utils"
 	"github.com/nikhiljohn10/uagplugin/logger"
@@ -16,7 +18,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// showMetadata shows metadata of a .so plugin file
+// showMetadata shows metadata of a compiled plugin file (.so or .rpc)
 var showMetadata = func(filePath string) {
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
@@ -39,17 +41,17 @@ var showMetadata = func(filePath string) {
 			return
 		}
 		for _, entry := range entries {
-			if !entry.IsDir() && strings.ToLower(entry.Name()) != ".so" && strings.HasSuffix(strings.ToLower(entry.Name()), ".so") {
+			if !entry.IsDir() && host.IsPluginFile(entry.Name()) {
 				pluginList = append(pluginList, filepath.Join(absPath, entry.Name()))
 			}
 		}
 		if len(pluginList) == 0 {
-			logger.Info("No .so plugin files found in directory: %s", absPath)
+			logger.Info("No plugin files found in directory: %s", absPath)
 			return
 		}
 	} else {
-		if !strings.HasSuffix(strings.ToLower(absPath), ".so") {
-			logger.Info("Provided path is not a .so file: %s", absPath)
+		if !host.IsPluginFile(absPath) {
+			logger.Info("Provided path is not a plugin file (.so or .rpc): %s", absPath)
 			return
 		}
 		pluginList = []string{absPath}
@@ -65,12 +67,20 @@ var showMetadata = func(filePath string) {
 			continue
 		}
 
+		if meta == nil {
+			logger.Error("Plugin %s returned no metadata", pluginPath)
+			continue
+		}
+
 		println(fmt.Sprintf("  Plugin: %s", pluginPath))
 		println(fmt.Sprintf("    Name:        %s", meta.Name))
 		println(fmt.Sprintf("    Version:     %s", meta.Version))
 		println(fmt.Sprintf("    Author:      %s", meta.Author))
 		println(fmt.Sprintf("    Description: %s", meta.Description))
 		println(fmt.Sprintf("    Contract:    %s", meta.ContractVersion))
+		if t, ok := host.TransportFor(pluginPath); ok {
+			println(fmt.Sprintf("    Runtime:     %s", t))
+		}
 	}
 }
 
@@ -99,25 +109,40 @@ var testPlugins = func(cmd *cobra.Command, args []string) {
 	mode, _ := cmd.Flags().GetString("mode")
 	jsonOut, _ := cmd.Flags().GetBool("json")
 
-	// Parse auth/params
+	// Parse auth/params; each accepts inline JSON or @file.json
 	var auth models.AuthCredentials = models.AuthCredentials{}
-	if s, _ := cmd.Flags().GetString("auth"); strings.TrimSpace(s) != "" {
-		if err := json.Unmarshal([]byte(s), &auth); err != nil {
-			logger.Warn("Invalid --auth JSON: %v", err)
-		}
+	if err := readJSONArg(mustString(cmd, "auth"), &auth); err != nil {
+		logger.Fatal("Invalid --auth: %v", err)
+		return
 	}
-	var contact_params models.ContactQueryParams
-	if s, _ := cmd.Flags().GetString("contact_params"); strings.TrimSpace(s) != "" {
-		if err := json.Unmarshal([]byte(s), &contact_params); err != nil {
-			logger.Warn("Invalid --contact_params JSON: %v", err)
-		}
+	var auth_params models.AuthParams
+	if err := readJSONArg(mustString(cmd, "auth-params"), &auth_params); err != nil {
+		logger.Fatal("Invalid --auth-params: %v", err)
+		return
 	}
+	var contact_params models.ContactQueryParams
 	var ledger_params models.LedgerQueryParams
-	if s, _ := cmd.Flags().GetString("ledger_params"); strings.TrimSpace(s) != "" {
-		if err := json.Unmarshal([]byte(s), &ledger_params); err != nil {
-			logger.Warn("Invalid --ledger_params JSON: %v", err)
+	// Deprecated --params seeds both; the fields it shares (cursor, limit, ...) apply to each.
+	if s := mustString(cmd, "params"); strings.TrimSpace(s) != "" {
+		if err := readJSONArg(s, &contact_params); err != nil {
+			logger.Fatal("Invalid --params: %v", err)
+			return
 		}
+		_ = readJSONArg(s, &ledger_params)
 	}
+	if err := readJSONArg(mustString(cmd, "contact-params"), &contact_params); err != nil {
+		logger.Fatal("Invalid --contact-params: %v", err)
+		return
+	}
+	if err := readJSONArg(mustString(cmd, "ledger-params"), &ledger_params); err != nil {
+		logger.Fatal("Invalid --ledger-params: %v", err)
+		return
+	}
+	if err := ledger_params.Validate(); err != nil {
+		logger.Fatal("Invalid --ledger-params: %v", err)
+		return
+	}
+	scenarioFile := mustString(cmd, "scenarios")
 
 	timeout := time.Duration(timeoutSec) * time.Second
 
@@ -132,15 +157,16 @@ var testPlugins = func(cmd *cobra.Command, args []string) {
 		}
 		abs, _ := filepath.Abs(p)
 		st, err := os.Stat(abs)
-		if err != nil {
+		if host.IsRemote(p) {
+			files = []string{p}
+		} else if err != nil {
 			logger.Fatal("path not found: %s", p)
 			return
-		}
-		if st.IsDir() {
+		} else if st.IsDir() {
 			searchDirs = []string{abs}
 		} else {
-			if !strings.HasSuffix(strings.ToLower(abs), ".so") {
-				logger.Fatal("file must be a .so shared object: %s", abs)
+			if !host.IsPluginFile(abs) {
+				logger.Fatal("file must be a compiled plugin (.so or .rpc): %s", abs)
 				return
 			}
 			files = []string{abs}
@@ -174,6 +200,21 @@ var testPlugins = func(cmd *cobra.Command, args []string) {
 		searchDirs = foundDirs
 	}
 
+	var creds *credstore.Store
+	profile := mustString(cmd, "profile")
+	if profile != "" {
+		if creds, err = credStore(); err != nil {
+			logger.Fatal("Failed to open the credential store: %v", err)
+			return
+		}
+	}
+
+	tokens, err := tokenStore()
+	if err != nil {
+		logger.Fatal("Failed to resolve the token store: %v", err)
+		return
+	}
+
 	// Run
 	res := plugintest.Run(cmd.Context(), plugintest.RunConfig{
 		BaseDir:       baseDir,
@@ -183,8 +224,13 @@ var testPlugins = func(cmd *cobra.Command, args []string) {
 		Timeout:       timeout,
 		Mode:          plugintest.ModeFromString(mode),
 		Auth:          auth,
+		AuthParams:    auth_params,
 		ContactParams: contact_params,
 		LedgerParams:  ledger_params,
+		ScenarioFile:  scenarioFile,
+		Tokens:        tokens,
+		Profile:       profile,
+		Credentials:   creds,
 		JSON:          jsonOut,
 	})
 
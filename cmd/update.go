@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+	"github.com/nikhiljohn10/uagplugin/internal/gitsource"
+	"github.com/nikhiljohn10/uagplugin/internal/plugintest"
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
+	"github.com/nikhiljohn10/uagplugin/internal/semver"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/spf13/cobra"
+)
+
+// updateOptions holds the flags of the update command.
+type updateOptions struct {
+	token   string
+	major   bool
+	check   bool
+	pre     bool
+	timeout time.Duration
+}
+
+// updateCandidate is the newest tag an installed plugin may move to.
+type updateCandidate struct {
+	ref     *plumbing.Reference
+	version semver.Version
+	// blockedMajor is a newer tag skipped because it crosses a major version.
+	blockedMajor *semver.Version
+}
+
+func pluginUpdate(cmd *cobra.Command, args []string) {
+	all, _ := cmd.Flags().GetBool("all")
+	opts := updateOptions{}
+	opts.token, _ = cmd.Flags().GetString("token")
+	opts.major, _ = cmd.Flags().GetBool("major")
+	opts.check, _ = cmd.Flags().GetBool("check")
+	opts.pre, _ = cmd.Flags().GetBool("pre")
+	timeoutSec, _ := cmd.Flags().GetInt("timeout")
+	opts.timeout = time.Duration(timeoutSec) * time.Second
+
+	if all == (len(args) == 1) {
+		logger.Error("Specify either a plugin name or --all.")
+		return
+	}
+
+	reg, err := registry.Load()
+	if err != nil {
+		logger.Error("%v", err)
+		return
+	}
+
+	var entries []registry.Entry
+	if all {
+		for _, e := range reg.List() {
+			if e.SourceType == registry.SourceRepo {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			logger.Info("No repository plugins installed.")
+			return
+		}
+	} else {
+		e, ok := reg.Get(args[0])
+		if !ok {
+			logger.Error("Plugin %q is not installed.", args[0])
+			return
+		}
+		if e.SourceType != registry.SourceRepo {
+			logger.Error("Plugin %q was installed from a local directory; reinstall it with 'uagplugin install dir %s'.", e.Name, e.Source)
+			return
+		}
+		entries = append(entries, e)
+	}
+
+	failed := false
+	for _, e := range entries {
+		if cmd.Context().Err() != nil {
+			return
+		}
+		if err := updateOne(cmd.Context(), e, opts); err != nil {
+			logger.Error("%s: %v", e.Name, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// updateOne fetches new tags for a repo plugin and, unless only checking,
+// rebuilds it at the newest allowed tag, rolling back if the new build fails.
+func updateOne(ctx context.Context, e registry.Entry, opts updateOptions) error {
+	baseDir, buildDir, err := utils.GetBaseAndBuildDir()
+	if err != nil {
+		return err
+	}
+	if e.Tag == "" && e.Ref != "" {
+		logger.Info("%s: installed from %s, not a release tag; reinstall to move it", e.Name, e.Ref)
+		return nil
+	}
+	srcDir := filepath.Join(baseDir, "pkgs", e.Name)
+	repo, err := git.PlainOpen(srcDir)
+	if err != nil {
+		return fmt.Errorf("source clone missing at %s, reinstall the plugin: %w", srcDir, err)
+	}
+	if err := gitsource.FetchTags(ctx, repo, gitsource.Credentials{Token: opts.token}); err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	var current *semver.Version
+	if e.Tag != "" {
+		if v, err := semver.Parse(e.Tag); err == nil {
+			current = &v
+		}
+	}
+	cand, err := findUpdate(repo, current, opts.major, opts.pre)
+	if err != nil {
+		return err
+	}
+	if cand.blockedMajor != nil {
+		logger.Info("%s: %s is available, use --major to cross the major version boundary", e.Name, cand.blockedMajor)
+	}
+	if cand.ref == nil {
+		logger.Info("%s: up to date (%s)", e.Name, orDash(e.Tag))
+		return nil
+	}
+	if opts.check {
+		logger.Info("%s: %s -> %s", e.Name, orDash(e.Tag), cand.version)
+		return nil
+	}
+
+	logger.Info("Updating %s: %s -> %s", e.Name, orDash(e.Tag), cand.version)
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to read current commit: %w", err)
+	}
+	prevCommit := head.Hash()
+
+	// Keep the working artifact aside until the new one has passed a smoke test.
+	backup := e.Artifact + ".bak"
+	hasBackup := false
+	if e.Artifact != "" {
+		if err := buildinfo.Rename(e.Artifact, backup); err == nil {
+			hasBackup = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to back up %s: %w", e.Artifact, err)
+		}
+	}
+	rollback := func(cause error) error {
+		if hasBackup {
+			if err := buildinfo.Rename(backup, e.Artifact); err != nil {
+				logger.Error("Failed to restore %s: %v", e.Artifact, err)
+			}
+		}
+		if err := gitsource.Checkout(repo, prevCommit); err != nil {
+			logger.Error("Failed to restore source to %s: %v", prevCommit, err)
+		}
+		logger.Warn("%s: rolled back to %s", e.Name, orDash(e.Tag))
+		return cause
+	}
+
+	// Annotated tags point at a tag object; check out the commit it refers to.
+	target, err := repo.ResolveRevision(plumbing.Revision(cand.ref.Name().String()))
+	if err != nil {
+		return rollback(fmt.Errorf("failed to resolve %s: %w", cand.ref.Name().Short(), err))
+	}
+	if err := gitsource.Checkout(repo, *target); err != nil {
+		return rollback(fmt.Errorf("failed to checkout %s: %w", cand.ref.Name().Short(), err))
+	}
+	artifact, err := utils.BuildPlugin(ctx, e.Name, srcDir, buildDir, e.Runtime)
+	if err != nil {
+		return rollback(fmt.Errorf("build failed: %w", err))
+	}
+	if err := smokeTest(ctx, artifact, opts.timeout); err != nil {
+		if artifact != e.Artifact {
+			_ = buildinfo.Remove(artifact)
+		}
+		return rollback(fmt.Errorf("smoke test failed: %w", err))
+	}
+	if hasBackup {
+		_ = buildinfo.Remove(backup)
+	}
+
+	e.Tag = cand.ref.Name().Short()
+	e.Commit = target.String()
+	if _, err := recordInstall(ctx, e, artifact); err != nil {
+		return err
+	}
+	logger.Info("Updated %s to %s", e.Name, e.Tag)
+	return nil
+}
+
+// findUpdate returns the newest semver tag greater than current. Unless
+// allowMajor is set, tags with a different major version are not candidates,
+// and pre-releases are skipped unless includePre is set or current is itself
+// a pre-release of the same version. A nil current (untagged install)
+// accepts any tag.
+func findUpdate(repo *git.Repository, current *semver.Version, allowMajor, includePre bool) (updateCandidate, error) {
+	var cand updateCandidate
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return cand, fmt.Errorf("failed to get tags: %w", err)
+	}
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		v, err := semver.Parse(ref.Name().Short())
+		if err != nil {
+			return nil // Ignore invalid tags
+		}
+		if v.IsPrerelease() && !includePre && !samePrereleaseLine(current, v) {
+			return nil
+		}
+		if current != nil && v.Compare(*current) <= 0 {
+			return nil
+		}
+		if current != nil && !allowMajor && v.Major != current.Major {
+			if cand.blockedMajor == nil || v.Compare(*cand.blockedMajor) > 0 {
+				blocked := v
+				cand.blockedMajor = &blocked
+			}
+			return nil
+		}
+		if cand.ref == nil || v.Compare(cand.version) > 0 {
+			cand.ref = ref
+			cand.version = v
+		}
+		return nil
+	})
+	if err != nil {
+		return cand, fmt.Errorf("failed to iterate over tags: %w", err)
+	}
+	return cand, nil
+}
+
+// samePrereleaseLine reports whether v is a later pre-release of the
+// version current is a pre-release of (1.3.0-rc.1 -> 1.3.0-rc.2).
+func samePrereleaseLine(current *semver.Version, v semver.Version) bool {
+	return current != nil && current.IsPrerelease() &&
+		current.Major == v.Major && current.Minor == v.Minor && current.Patch == v.Patch
+}
+
+// smokeTest loads the artifact and exercises it like `uagplugin test`. Only
+// failures that show the build is unusable (load errors, incompatible
+// contracts, panics, timeouts) fail the update; call errors are expected for
+// plugins that need credentials and are only reported.
+func smokeTest(ctx context.Context, artifact string, timeout time.Duration) error {
+	rr := plugintest.Run(ctx, plugintest.RunConfig{
+		Files:   []string{artifact},
+		Timeout: timeout,
+		Mode:    plugintest.ModeSmoke,
+	})
+	if len(rr.Plugins) == 0 {
+		return fmt.Errorf("%s was not found", artifact)
+	}
+	var problems []string
+	for _, fr := range rr.Plugins[0].Funcs {
+		switch {
+		case fr.Status == "ok" || fr.Status == "missing" || fr.Status == "skipped":
+		case fr.Status == "error" && (fr.Name == "AuthType" || fr.Name == "OAuth2" || fr.Name == "Auth" || fr.Name == "Contacts" || fr.Name == "Ledger" || fr.Name == "RunTests"):
+			logger.Warn("%s: %s", fr.Name, fr.Error)
+		default:
+			msg := fr.Error
+			if fr.Status == "panic" {
+				msg = fr.Panic
+			}
+			problems = append(problems, fmt.Sprintf("%s %s: %s", fr.Name, fr.Status, msg))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -11,6 +11,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -34,6 +35,7 @@ type ApiPlugin struct{}
 // Export typed Plugin symbol
 var Plugin typing.Plugin = ApiPlugin{}
 var _ typing.Plugin = (*ApiPlugin)(nil)
+var _ typing.ContextPlugin = (*ApiPlugin)(nil)
 
 func (ApiPlugin) Meta() *models.MetaData {
 	return &models.MetaData{
@@ -49,6 +51,9 @@ func (ApiPlugin) Meta() *models.MetaData {
 // Health returns a simple constant to indicate the plugin is responsive.
 func (ApiPlugin) Health() string { return "ok" }
 
+// HealthContext implements typing.ContextPlugin.
+func (p ApiPlugin) HealthContext(ctx context.Context) string { return p.Health() }
+
 // API user shape we care about
 type apiUser struct {
 	ID    int    `json:"id"`
@@ -82,12 +87,21 @@ func baseURL(data map[string]string) string {
 //
 // If the API requires authentication in the future, headers can be set based on
 // the provided AuthCredentials (e.g., bearer token).
-func (ApiPlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+func (p ApiPlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	return p.ContactsContext(context.Background(), auth, params)
+}
+
+// ContactsContext implements typing.ContextPlugin; the API request is aborted
+// when the host cancels ctx (e.g. on timeout).
+func (ApiPlugin) ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
 	// Build URL of the users endpoint
 	url := strings.TrimRight(baseURL(params.Extras), "/") + "/users"
 
 	// Fetch users from API
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
 	// If future auth is required, read from auth and set headers here
 	// Example: if token, ok := auth["token"]; ok { req.Header.Set("Authorization", "Bearer "+token) }
 	resp, err := http.DefaultClient.Do(req)
@@ -155,7 +169,12 @@ func (ApiPlugin) Contacts(auth models.AuthCredentials, params models.ContactQuer
 
 // Ledger implements the core interface; demo returns an empty ledger.
 func (ApiPlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
-	return &models.Ledger{Entries: nil, CustomerName: "", OpeningBalance: "0"}, nil
+	return &models.Ledger{Entries: nil, CustomerName: "", OpeningBalance: models.MustParseMoney("0")}, nil
+}
+
+// LedgerContext implements typing.ContextPlugin.
+func (p ApiPlugin) LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	return p.Ledger(auth, params)
 }
 
 // Back-compat: keep top-level functions delegating to the instance
@@ -86,11 +86,11 @@ func (p *BasePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQue
 		ID:      1,
 		Date:    "2024-01-01",
 		DocType: models.DocTypeInvoice,
-		Amount:  "100.00",
+		Amount:  models.MustParseMoney("100.00"),
 	}
 	return &models.Ledger{
 		Entries:        []models.LedgerEntry{entry},
 		CustomerName:   "Authenticated Customer",
-		OpeningBalance: "0.00",
+		OpeningBalance: models.MustParseMoney("0.00"),
 	}, nil
 }
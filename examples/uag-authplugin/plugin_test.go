@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nikhiljohn10/uagplugin/models"
+	tk "github.com/nikhiljohn10/uagplugin/testkit"
 )
 
 // TestMeta verifies that the plugin's metadata is returned correctly.
@@ -178,3 +179,8 @@ func TestLedger(t *testing.T) {
 		t.Errorf("Expected DocType to be 'invoice', got '%s'", ledger.Entries[0].DocType)
 	}
 }
+
+// TestConformance runs the shared plugin contract checklist.
+func TestConformance(t *testing.T) {
+	tk.RunConformance(t, Plugin)
+}
@@ -91,6 +91,12 @@ func (filePlugin) Contacts(auth models.AuthCredentials, params models.ContactQue
 func (filePlugin) Health() string { return "ok" }
 
 // Ledger reads the embedded CSV and returns a paginated list of ledger entries.
+//
+// Supported Params:
+//   - CustomerID: restrict entries to one customer; all customers when empty
+//   - DocTypes:   restrict entries to the given document types
+//   - StartDate/EndDate: inclusive date range (YYYY-MM-DD), either may be empty
+//   - Cursor: cursor-based pagination position (base64 encoded index)
 func (filePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
 	r := csv.NewReader(strings.NewReader(ledgerCSV))
 	records, err := r.ReadAll()
@@ -100,22 +106,40 @@ func (filePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryP
 
 	var entries []models.LedgerEntry
 	for _, record := range records[1:] { // Skip header
+		// Filter by customer, if provided
+		if params.CustomerID != "" && record[1] != params.CustomerID {
+			continue
+		}
+		docType := models.DocType(record[3])
+		// Filter by document types, if provided
+		if len(params.DocTypes) > 0 && !slices.Contains(params.DocTypes, docType) {
+			continue
+		}
 		id, _ := strconv.ParseInt(record[0], 10, 64)
+		amount, err := models.ParseMoney(record[5])
+		if err != nil {
+			return nil, err
+		}
 		entries = append(entries, models.LedgerEntry{
 			ID:      id,
-			Date:    record[1],
-			DocType: models.DocType(record[2]),
-			Amount:  record[3],
+			Date:    record[2],
+			DocType: docType,
+			Amount:  amount,
 		})
 	}
 
+	entries, err = utils.FilterByDateRange(entries, params.StartDate, params.EndDate, func(e models.LedgerEntry) string { return e.Date })
+	if err != nil {
+		return nil, err
+	}
+
 	// Paginate with a page size of 5
 	paginatedEntries, nextCursor := utils.PaginateCursor(entries, params.Cursor, 5)
 
 	return &models.Ledger{
 		Entries:        paginatedEntries,
 		CustomerName:   "File-based Customer",
-		OpeningBalance: "100.00",
+		OpeningBalance: models.MustParseMoney("100.00"),
 		NextCursor:     nextCursor,
 	}, nil
 }
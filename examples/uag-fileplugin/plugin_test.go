@@ -44,3 +44,23 @@ func TestLedgerFilterByIDs(t *testing.T) {
 		t.Errorf("Expected 2 ledger entries, got %d", len(lg.Entries))
 	}
 }
+
+func TestLedgerFilterByDocTypes(t *testing.T) {
+	lg, err := Ledger(nil, models.LedgerQueryParams{DocTypes: []models.DocType{models.DocTypePayment}, StartDate: "2025-01-01", EndDate: "2025-01-31"})
+	if err != nil {
+		t.Fatalf("Ledger error: %v", err)
+	}
+	if len(lg.Entries) != 4 {
+		t.Errorf("Expected 4 ledger entries, got %d", len(lg.Entries))
+	}
+	for _, e := range lg.Entries {
+		if e.DocType != models.DocTypePayment {
+			t.Errorf("Expected only payments, got %s", e.DocType)
+		}
+	}
+}
+
+// TestConformance runs the shared plugin contract checklist.
+func TestConformance(t *testing.T) {
+	tk.RunConformance(t, Plugin)
+}
@@ -0,0 +1,201 @@
+package host
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+)
+
+// ErrABIMismatch is wrapped by *ABIMismatchError.
+var ErrABIMismatch = errors.New("plugin ABI mismatch")
+
+// ABIMismatch is one difference between how a native plugin and the host
+// were built.
+type ABIMismatch struct {
+	Field  string
+	Plugin string
+	Host   string
+	Fix    string
+}
+
+// ABIMismatchError is returned by Open instead of attempting plugin.Open on a
+// shared object that would fail with "plugin was built with a different
+// version of package".
+type ABIMismatchError struct {
+	Path       string
+	Mismatches []ABIMismatch
+}
+
+func (e *ABIMismatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s was built for a different host and cannot be loaded:", e.Path)
+	for _, m := range e.Mismatches {
+		fmt.Fprintf(&b, "\n  - %s: plugin %s, host %s", m.Field, m.Plugin, m.Host)
+		if m.Fix != "" {
+			fmt.Fprintf(&b, "\n    fix: %s", m.Fix)
+		}
+	}
+	b.WriteString("\n  Reinstall the plugin with this uagplugin binary, or install it with --runtime rpc, which has no such constraints.")
+	return b.String()
+}
+
+func (e *ABIMismatchError) Unwrap() error { return ErrABIMismatch }
+
+// CheckABI compares the build of the native plugin at path with the running
+// host. It reads the sidecar written at install time, falling back to the
+// build information embedded in the shared object for older installs. When
+// the plugin or the host carries no build information the check is skipped.
+func CheckABI(path string) error {
+	pi, err := buildinfo.Read(path)
+	if err != nil || pi.GOOS == "" {
+		if pi, err = buildinfo.FromArtifact(path); err != nil {
+			return nil
+		}
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	if ms := compareABI(pi, buildinfo.FromBuildInfo(bi), hostModuleDir()); len(ms) > 0 {
+		return &ABIMismatchError{Path: path, Mismatches: ms}
+	}
+	return nil
+}
+
+// hostModuleDir returns the uagplugin source directory the host was compiled
+// from, or "" for -trimpath builds and module cache copies.
+func hostModuleDir() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok || !filepath.IsAbs(file) {
+		return ""
+	}
+	dir := filepath.Dir(filepath.Dir(file))
+	if strings.Contains(filepath.Base(dir), "@") {
+		return "" // module cache: uagplugin@vX.Y.Z
+	}
+	return dir
+}
+
+func compareABI(p, h *buildinfo.Info, hostDir string) []ABIMismatch {
+	var ms []ABIMismatch
+	if p.GoVersion != h.GoVersion {
+		ms = append(ms, ABIMismatch{
+			Field: "Go toolchain", Plugin: p.GoVersion, Host: h.GoVersion,
+			Fix: fmt.Sprintf("rebuild the plugin with %s (e.g. GOTOOLCHAIN=%s), or rebuild uagplugin with %s", h.GoVersion, h.GoVersion, p.GoVersion),
+		})
+	}
+	// Sidecars written before these fields existed leave them empty.
+	if p.GOOS != "" && (p.GOOS != h.GOOS || p.GOARCH != h.GOARCH) {
+		ms = append(ms, ABIMismatch{
+			Field: "platform", Plugin: p.GOOS + "/" + p.GOARCH, Host: h.GOOS + "/" + h.GOARCH,
+			Fix: "rebuild the plugin on this machine",
+		})
+	}
+	if p.GOOS != "" && p.Trimpath != h.Trimpath {
+		ms = append(ms, ABIMismatch{
+			Field: "-trimpath", Plugin: onOff(p.Trimpath), Host: onOff(h.Trimpath),
+			Fix: "build the plugin and uagplugin with the same -trimpath setting",
+		})
+	}
+	if p.GOOS != "" && !sameTags(p.Tags, h.Tags) {
+		ms = append(ms, ABIMismatch{
+			Field: "build tags", Plugin: orNone(p.Tags), Host: orNone(h.Tags),
+			Fix: "build the plugin with -tags=" + h.Tags,
+		})
+	}
+	if m, ok := compareModule(p, h, hostDir); !ok {
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+// compareModule checks that plugin and host link the same uagplugin module.
+// Without -trimpath source paths are part of each package's identity, so a
+// local checkout only matches the same checkout.
+func compareModule(p, h *buildinfo.Info, hostDir string) (ABIMismatch, bool) {
+	if p.UAGPlugin == nil || h.UAGPlugin == nil {
+		return ABIMismatch{}, true
+	}
+	pm, hm := *p.UAGPlugin, *h.UAGPlugin
+	pDir, pLocal := pm.LocalDir()
+	hDir, hLocal := hm.LocalDir()
+	if !hLocal && hostDir != "" {
+		hDir, hLocal = hostDir, true
+	}
+	mismatch := ABIMismatch{Field: buildinfo.ModulePath, Plugin: describeModule(pm, pDir, pLocal), Host: describeModule(hm, hDir, hLocal)}
+	switch {
+	case pLocal && hLocal:
+		if p.Trimpath && h.Trimpath || filepath.Clean(pDir) == filepath.Clean(hDir) {
+			return ABIMismatch{}, true
+		}
+		mismatch.Fix = "run the install from the uagplugin checkout at " + hDir
+	case pLocal || hLocal:
+		if p.Trimpath && h.Trimpath {
+			return ABIMismatch{}, true
+		}
+		if hLocal {
+			mismatch.Fix = "run the install from the uagplugin checkout at " + hDir
+		} else {
+			mismatch.Fix = fmt.Sprintf("build the plugin against %s %s instead of a local checkout", buildinfo.ModulePath, effective(hm).Version)
+		}
+	default:
+		pe, he := effective(pm), effective(hm)
+		if pe.Path == he.Path && pe.Version == he.Version && (pe.Sum == "" || he.Sum == "" || pe.Sum == he.Sum) {
+			return ABIMismatch{}, true
+		}
+		mismatch.Fix = fmt.Sprintf("require %s %s in the plugin's go.mod", he.Path, he.Version)
+	}
+	return mismatch, false
+}
+
+// effective returns the module actually used after replacement.
+func effective(m buildinfo.Module) buildinfo.Module {
+	if m.Replace != nil {
+		return *m.Replace
+	}
+	return m
+}
+
+func describeModule(m buildinfo.Module, dir string, local bool) string {
+	if local {
+		return "local checkout " + dir
+	}
+	e := effective(m)
+	s := e.Version
+	if e.Path != m.Path {
+		s = e.Path + " " + s
+	}
+	if e.Sum != "" {
+		s += " (" + e.Sum + ")"
+	}
+	return s
+}
+
+func sameTags(a, b string) bool {
+	split := func(s string) []string {
+		tags := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+		slices.Sort(tags)
+		return tags
+	}
+	return slices.Equal(split(a), split(b))
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package host
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+)
+
+func TestCompareABI(t *testing.T) {
+	local := func(dir string) *buildinfo.Module {
+		return &buildinfo.Module{Path: buildinfo.ModulePath, Version: "v0.2.1", Replace: &buildinfo.Module{Path: dir, Version: "(devel)"}}
+	}
+	release := func(version, sum string) *buildinfo.Module {
+		return &buildinfo.Module{Path: buildinfo.ModulePath, Version: version, Sum: sum}
+	}
+	info := func(mod *buildinfo.Module, edit func(*buildinfo.Info)) *buildinfo.Info {
+		i := &buildinfo.Info{GoVersion: "go1.25.1", GOOS: "linux", GOARCH: "amd64", UAGPlugin: mod}
+		if edit != nil {
+			edit(i)
+		}
+		return i
+	}
+
+	cases := []struct {
+		name    string
+		plugin  *buildinfo.Info
+		host    *buildinfo.Info
+		hostDir string
+		fields  []string
+	}{
+		{
+			name:    "same checkout",
+			plugin:  info(local("/src/uagplugin"), nil),
+			host:    info(release("v0.0.0-20250101000000-abcdef123456+dirty", ""), nil),
+			hostDir: "/src/uagplugin",
+		},
+		{
+			name:   "same release",
+			plugin: info(release("v0.3.0", "h1:a="), nil),
+			host:   info(release("v0.3.0", "h1:a="), nil),
+		},
+		{
+			name:   "different toolchain and platform",
+			plugin: info(release("v0.3.0", ""), func(i *buildinfo.Info) { i.GoVersion, i.GOARCH = "go1.24.0", "arm64" }),
+			host:   info(release("v0.3.0", ""), nil),
+			fields: []string{"Go toolchain", "platform"},
+		},
+		{
+			name:   "trimpath and tags",
+			plugin: info(release("v0.3.0", ""), func(i *buildinfo.Info) { i.Trimpath, i.Tags = true, "b,a" }),
+			host:   info(release("v0.3.0", ""), func(i *buildinfo.Info) { i.Tags = "a,b" }),
+			fields: []string{"-trimpath"},
+		},
+		{
+			name:    "other checkout",
+			plugin:  info(local("/tmp/other"), nil),
+			host:    info(release("v0.3.0", ""), nil),
+			hostDir: "/src/uagplugin",
+			fields:  []string{buildinfo.ModulePath},
+		},
+		{
+			name:   "checkout against release host",
+			plugin: info(local("/src/uagplugin"), nil),
+			host:   info(release("v0.3.0", ""), nil),
+			fields: []string{buildinfo.ModulePath},
+		},
+		{
+			name:   "different release",
+			plugin: info(release("v0.2.1", ""), nil),
+			host:   info(release("v0.3.0", ""), nil),
+			fields: []string{buildinfo.ModulePath},
+		},
+		{
+			name:   "same version with different sums",
+			plugin: info(release("v0.3.0", "h1:a="), nil),
+			host:   info(release("v0.3.0", "h1:b="), nil),
+			fields: []string{buildinfo.ModulePath},
+		},
+	}
+	for _, tc := range cases {
+		t.Run("should compare "+tc.name, func(t *testing.T) {
+			var got []string
+			for _, m := range compareABI(tc.plugin, tc.host, tc.hostDir) {
+				got = append(got, m.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.fields, ",") {
+				t.Errorf("Expected mismatches %v, got %v", tc.fields, got)
+			}
+		})
+	}
+}
+
+func TestABIMismatchError(t *testing.T) {
+	err := error(&ABIMismatchError{
+		Path:       "/p.so",
+		Mismatches: []ABIMismatch{{Field: "Go toolchain", Plugin: "go1.24.0", Host: "go1.25.1", Fix: "rebuild"}},
+	})
+	if !errors.Is(err, ErrABIMismatch) {
+		t.Errorf("Expected error to wrap ErrABIMismatch")
+	}
+	for _, want := range []string{"/p.so", "Go toolchain: plugin go1.24.0, host go1.25.1", "fix: rebuild", "--runtime rpc"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, err)
+		}
+	}
+}
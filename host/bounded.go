@@ -0,0 +1,37 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+)
+
+// Bounded runs fn, returning early with ctx's error if ctx ends first, as
+// not every plugin honours contexts. A panic in an in-process plugin is
+// returned as a *pluginrpc.PanicError instead of stopping the host.
+func Bounded[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			if v := recover(); v != nil {
+				r.err = &pluginrpc.PanicError{Value: fmt.Sprint(v), Stack: string(debug.Stack())}
+			}
+			done <- r
+		}()
+		r.v, r.err = fn()
+	}()
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,38 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+)
+
+func TestBounded(t *testing.T) {
+	t.Run("should return the result", func(t *testing.T) {
+		v, err := Bounded(context.Background(), func() (int, error) { return 42, nil })
+		if v != 42 || err != nil {
+			t.Errorf("Expected 42, got %d, %v", v, err)
+		}
+	})
+
+	t.Run("should return early when the context ends", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := Bounded(ctx, func() (int, error) { <-release; return 0, nil })
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("should return panics as PanicError", func(t *testing.T) {
+		_, err := Bounded(context.Background(), func() (int, error) { panic("boom") })
+		var pe *pluginrpc.PanicError
+		if !errors.As(err, &pe) || pe.Value != "boom" {
+			t.Errorf("Expected PanicError 'boom', got %v", err)
+		}
+	})
+}
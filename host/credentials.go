@@ -0,0 +1,178 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+var (
+	// ErrUnknownAuthType is wrapped by *UnknownAuthTypeError.
+	ErrUnknownAuthType = errors.New("unknown auth type")
+	// ErrMissingCredentials is wrapped by *MissingCredentialsError.
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrNoAuthenticator is returned by Authenticate for plugins without Auth.
+	ErrNoAuthenticator = errors.New("plugin does not implement Auth")
+)
+
+// Declarations checked by the credential validators.
+const (
+	DeclAuthCredentials = "auth_credentials"
+	DeclAPICredentials  = "api_credentials"
+)
+
+// UnknownAuthTypeError reports a MetaData.AuthType outside models.AuthTypes.
+type UnknownAuthTypeError struct {
+	AuthType models.AuthType
+}
+
+func (e *UnknownAuthTypeError) Error() string {
+	known := make([]string, len(models.AuthTypes))
+	for i, t := range models.AuthTypes {
+		known[i] = string(t)
+	}
+	return fmt.Sprintf("unknown auth_type %q (expected %s)", e.AuthType, strings.Join(known, ", "))
+}
+
+func (e *UnknownAuthTypeError) Unwrap() error { return ErrUnknownAuthType }
+
+// MissingCredentialsError lists keys declared in MetaData that a credentials
+// map lacks: the Auth input for DeclAuthCredentials, its result for
+// DeclAPICredentials.
+type MissingCredentialsError struct {
+	Declaration string
+	Keys        []string
+	// Descriptions holds the declared description of each missing input key.
+	Descriptions map[string]string
+}
+
+func (e *MissingCredentialsError) Error() string {
+	parts := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		parts[i] = k
+		if d := e.Descriptions[k]; d != "" {
+			parts[i] += " (" + d + ")"
+		}
+	}
+	if e.Declaration == DeclAPICredentials {
+		return "Auth result is missing declared api_credentials: " + strings.Join(parts, ", ")
+	}
+	return "missing declared auth_credentials: " + strings.Join(parts, ", ")
+}
+
+func (e *MissingCredentialsError) Unwrap() error { return ErrMissingCredentials }
+
+// ValidateAuthType checks meta.AuthType against models.AuthTypes.
+func ValidateAuthType(meta *models.MetaData) error {
+	if meta == nil || meta.AuthType.Valid() {
+		return nil
+	}
+	return &UnknownAuthTypeError{AuthType: meta.AuthType}
+}
+
+// ValidateAuthParams checks that params supply a non-empty value for every key
+// in meta.AuthCredentials. The api_key, client_id and client_secret keys map
+// to the AuthParams fields; any other key is looked up in params.Data.
+func ValidateAuthParams(meta *models.MetaData, params models.AuthParams) error {
+	if meta == nil || meta.AuthCredentials == nil {
+		return nil
+	}
+	declared := *meta.AuthCredentials
+	values := authParamValues(params)
+	var missing []string
+	for k := range declared {
+		if values[k] == "" {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	desc := make(map[string]string, len(missing))
+	for _, k := range missing {
+		desc[k] = declared[k]
+	}
+	return &MissingCredentialsError{Declaration: DeclAuthCredentials, Keys: missing, Descriptions: desc}
+}
+
+// ValidateAPICredentials checks that creds, as returned by Auth, hold a
+// non-empty value for every key in meta.ApiCredentials.
+func ValidateAPICredentials(meta *models.MetaData, creds *models.AuthCredentials) error {
+	if meta == nil || meta.ApiCredentials == nil {
+		return nil
+	}
+	var got models.AuthCredentials
+	if creds != nil {
+		got = *creds
+	}
+	var missing []string
+	for _, k := range *meta.ApiCredentials {
+		if got[k] == "" && !slices.Contains(missing, k) {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	return &MissingCredentialsError{Declaration: DeclAPICredentials, Keys: missing}
+}
+
+// ValidatedAuth validates params against meta, calls auth and validates the
+// credentials it returns.
+func ValidatedAuth(meta *models.MetaData, params models.AuthParams, auth func(models.AuthParams) (*models.AuthCredentials, error)) (*models.AuthCredentials, error) {
+	if err := ValidateAuthParams(meta, params); err != nil {
+		return nil, err
+	}
+	creds, err := auth(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateAPICredentials(meta, creds); err != nil {
+		return creds, err
+	}
+	return creds, nil
+}
+
+// Authenticate calls the plugin's Auth, validating its input and result
+// against the plugin's metadata.
+func (p *Plugin) Authenticate(params models.AuthParams) (*models.AuthCredentials, error) {
+	return p.AuthenticateContext(context.Background(), params)
+}
+
+// AuthenticateContext is Authenticate with ctx passed on to the Meta and
+// Auth calls of remote plugins.
+func (p *Plugin) AuthenticateContext(ctx context.Context, params models.AuthParams) (*models.AuthCredentials, error) {
+	a, ok := p.Authenticator()
+	if !ok {
+		return nil, ErrNoAuthenticator
+	}
+	meta, err := p.MetaContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth := a.Auth
+	if ac, ok := a.(authContext); ok {
+		auth = func(params models.AuthParams) (*models.AuthCredentials, error) { return ac.AuthContext(ctx, params) }
+	}
+	return ValidatedAuth(meta, params, auth)
+}
+
+func authParamValues(params models.AuthParams) map[string]string {
+	values := map[string]string{}
+	if params.Data != nil {
+		maps.Copy(values, *params.Data)
+	}
+	for k, v := range map[string]string{"api_key": params.APIKey, "client_id": params.ClientID, "client_secret": params.ClientSecret} {
+		if v != "" {
+			values[k] = v
+		}
+	}
+	return values
+}
@@ -0,0 +1,95 @@
+package host
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	meta := &models.MetaData{
+		AuthType: models.AuthTypeAPIKey,
+		AuthCredentials: &models.InitialAuthCredentials{
+			"api_key":         "API key",
+			"organization_id": "Organization ID",
+		},
+		ApiCredentials: &models.ApiCredentials{"token", "expires_in"},
+	}
+
+	t.Run("should accept known auth types", func(t *testing.T) {
+		for _, at := range models.AuthTypes {
+			if err := ValidateAuthType(&models.MetaData{AuthType: at}); err != nil {
+				t.Errorf("Expected %q to be valid, got %v", at, err)
+			}
+		}
+	})
+
+	t.Run("should reject unknown auth types", func(t *testing.T) {
+		for _, at := range []models.AuthType{"", "basic", "API_KEY"} {
+			err := ValidateAuthType(&models.MetaData{AuthType: at})
+			var e *UnknownAuthTypeError
+			if !errors.As(err, &e) || e.AuthType != at || !errors.Is(err, ErrUnknownAuthType) {
+				t.Errorf("Expected UnknownAuthTypeError for %q, got %v", at, err)
+			}
+		}
+	})
+
+	t.Run("should accept params with every declared key", func(t *testing.T) {
+		params := models.AuthParams{APIKey: "k", Data: &map[string]string{"organization_id": "acme"}}
+		if err := ValidateAuthParams(meta, params); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should list missing auth credentials with descriptions", func(t *testing.T) {
+		err := ValidateAuthParams(meta, models.AuthParams{Data: &map[string]string{"organization_id": ""}})
+		var e *MissingCredentialsError
+		if !errors.As(err, &e) || !errors.Is(err, ErrMissingCredentials) {
+			t.Fatalf("Expected MissingCredentialsError, got %v", err)
+		}
+		if e.Declaration != DeclAuthCredentials || !slices.Equal(e.Keys, []string{"api_key", "organization_id"}) {
+			t.Errorf("Expected api_key and organization_id missing, got %+v", e)
+		}
+		if !strings.Contains(err.Error(), "api_key (API key)") {
+			t.Errorf("Expected description in message, got %q", err)
+		}
+	})
+
+	t.Run("should check the Auth result against api credentials", func(t *testing.T) {
+		if err := ValidateAPICredentials(meta, &models.AuthCredentials{"token": "t", "expires_in": "3600"}); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		err := ValidateAPICredentials(meta, &models.AuthCredentials{"token": "t"})
+		var e *MissingCredentialsError
+		if !errors.As(err, &e) || e.Declaration != DeclAPICredentials || !slices.Equal(e.Keys, []string{"expires_in"}) {
+			t.Errorf("Expected expires_in missing, got %v", err)
+		}
+		if err := ValidateAPICredentials(meta, nil); err == nil {
+			t.Errorf("Expected error for nil credentials")
+		}
+	})
+
+	t.Run("should not call Auth with invalid params", func(t *testing.T) {
+		called := false
+		_, err := ValidatedAuth(meta, models.AuthParams{}, func(models.AuthParams) (*models.AuthCredentials, error) {
+			called = true
+			return nil, nil
+		})
+		if called || !errors.Is(err, ErrMissingCredentials) {
+			t.Errorf("Expected validation error before Auth, got called=%v err=%v", called, err)
+		}
+	})
+
+	t.Run("should skip checks without declarations", func(t *testing.T) {
+		bare := &models.MetaData{AuthType: models.AuthTypeNone}
+		if err := ValidateAuthParams(bare, models.AuthParams{}); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := ValidateAPICredentials(bare, nil); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
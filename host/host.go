@@ -0,0 +1,345 @@
+// Package host loads UAG plugins for use by the CLI and other host programs.
+//
+// Two transports are supported:
+//   - native: a Go shared object (.so) loaded in-process with plugin.Open
+//   - rpc:    a standalone executable (.rpc) driven over JSON-RPC on stdin/stdout
+//
+// The transport is picked per plugin from the file suffix. Plugins served by
+// another process are opened from URL-like targets (grpc://host:port/name)
+// by the transport registered for the scheme with RegisterOpener.
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+type Transport string
+
+const (
+	TransportNative Transport = "native"
+	TransportRPC    Transport = "rpc"
+	// TransportGRPC is a plugin served by another host; see package plugingrpc.
+	TransportGRPC Transport = "grpc"
+)
+
+// File suffixes used for compiled plugins of each transport.
+const (
+	NativeSuffix = ".so"
+	RPCSuffix    = ".rpc"
+)
+
+// ErrNoPluginSymbol is returned when a shared object does not export a usable
+// typing.Plugin value named "Plugin".
+var ErrNoPluginSymbol = errors.New("no usable 'Plugin' symbol")
+
+// TransportFromString parses a --runtime value. Empty means native; only the
+// transports a plugin can be built for are accepted.
+func TransportFromString(s string) (Transport, error) {
+	switch t := Transport(strings.ToLower(strings.TrimSpace(s))); t {
+	case "":
+		return TransportNative, nil
+	case TransportNative, TransportRPC:
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown runtime %q (choose native or rpc)", s)
+}
+
+// Suffix returns the compiled file suffix for the transport.
+func (t Transport) Suffix() string {
+	if t == TransportRPC {
+		return RPCSuffix
+	}
+	return NativeSuffix
+}
+
+// TransportFor detects the transport from a compiled plugin path.
+func TransportFor(path string) (Transport, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case NativeSuffix:
+		return TransportNative, true
+	case RPCSuffix:
+		return TransportRPC, true
+	}
+	return "", false
+}
+
+// IsPluginFile reports whether path looks like a compiled plugin of any transport.
+func IsPluginFile(path string) bool {
+	base := filepath.Base(path)
+	_, ok := TransportFor(base)
+	return ok && base != filepath.Ext(base)
+}
+
+// PluginName returns the plugin name derived from a compiled plugin path.
+func PluginName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// Plugin is a loaded plugin regardless of transport. It implements
+// typing.ContextPlugin, falling back to the plain methods for plugins that
+// do not support contexts.
+type Plugin struct {
+	typing.Plugin
+	Path      string
+	Transport Transport
+
+	auth   typing.Authenticator
+	tester typing.Tester
+	close  func() error
+}
+
+var _ typing.ContextPlugin = (*Plugin)(nil)
+
+// Authenticator returns the optional Authenticator implementation.
+func (p *Plugin) Authenticator() (typing.Authenticator, bool) {
+	return p.auth, p.auth != nil
+}
+
+// Tester returns the optional Tester implementation.
+func (p *Plugin) Tester() (typing.Tester, bool) {
+	return p.tester, p.tester != nil
+}
+
+// metaContext, authContext and testsContext are implemented by the RPC and
+// gRPC clients, whose calls can fail or hang; their plain Meta returns nil
+// on failure and none of the plain methods take a deadline.
+type metaContext interface {
+	MetaContext(ctx context.Context) (*models.MetaData, error)
+}
+
+type authContext interface {
+	AuthContext(ctx context.Context, params models.AuthParams) (*models.AuthCredentials, error)
+}
+
+type testsContext interface {
+	RunTestsContext(ctx context.Context) error
+}
+
+// RunTestsContext calls the plugin's RunTests, passing ctx on to remote
+// plugins. It returns pluginrpc.ErrUnsupported for plugins without one.
+func (p *Plugin) RunTestsContext(ctx context.Context) error {
+	if p.tester == nil {
+		return pluginrpc.ErrUnsupported
+	}
+	if tc, ok := p.tester.(testsContext); ok {
+		return tc.RunTestsContext(ctx)
+	}
+	return p.tester.RunTests()
+}
+
+// MetaContext returns the plugin's metadata, or the error of a remote Meta
+// call that failed, including a panic recovered in the plugin process.
+func (p *Plugin) MetaContext(ctx context.Context) (*models.MetaData, error) {
+	if mc, ok := p.Plugin.(metaContext); ok {
+		return mc.MetaContext(ctx)
+	}
+	return p.Meta(), nil
+}
+
+// HealthContext calls the plugin's context-aware Health when available.
+func (p *Plugin) HealthContext(ctx context.Context) string {
+	if cp, ok := p.Plugin.(typing.ContextPlugin); ok {
+		return cp.HealthContext(ctx)
+	}
+	return p.Health()
+}
+
+// ContactsContext calls the plugin's context-aware Contacts when available.
+func (p *Plugin) ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	if cp, ok := p.Plugin.(typing.ContextPlugin); ok {
+		return cp.ContactsContext(ctx, auth, params)
+	}
+	return p.Contacts(auth, params)
+}
+
+// LedgerContext calls the plugin's context-aware Ledger when available.
+func (p *Plugin) LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	if cp, ok := p.Plugin.(typing.ContextPlugin); ok {
+		return cp.LedgerContext(ctx, auth, params)
+	}
+	return p.Ledger(auth, params)
+}
+
+// Close releases the plugin. Native plugins cannot be unloaded, so this is a
+// no-op for them; RPC plugins have their process shut down and remote ones
+// their connection closed.
+func (p *Plugin) Close() error {
+	if p.close == nil {
+		return nil
+	}
+	return p.close()
+}
+
+// Opener opens a plugin from a URL-like target.
+type Opener func(ctx context.Context, target string) (*Plugin, error)
+
+var (
+	openersMu sync.RWMutex
+	openers   = map[string]Opener{}
+)
+
+// RegisterOpener makes Open hand targets of the form scheme://... to open.
+// Remote transports call it from an init function.
+func RegisterOpener(scheme string, open Opener) {
+	openersMu.Lock()
+	defer openersMu.Unlock()
+	openers[strings.ToLower(scheme)] = open
+}
+
+func openerFor(target string) (Opener, bool) {
+	scheme, _, ok := strings.Cut(target, "://")
+	if !ok {
+		return nil, false
+	}
+	openersMu.RLock()
+	defer openersMu.RUnlock()
+	open, ok := openers[strings.ToLower(scheme)]
+	return open, ok
+}
+
+// IsRemote reports whether target is handled by a registered Opener rather
+// than being a plugin file.
+func IsRemote(target string) bool {
+	_, ok := openerFor(target)
+	return ok
+}
+
+// Open loads the plugin at path using the transport implied by its suffix,
+// or connects to it when path is a target of a registered Opener.
+func Open(ctx context.Context, path string) (*Plugin, error) {
+	if open, ok := openerFor(path); ok {
+		return open(ctx, path)
+	}
+	t, ok := TransportFor(path)
+	if !ok {
+		return nil, fmt.Errorf("unrecognised plugin file %s (expected %s or %s)", path, NativeSuffix, RPCSuffix)
+	}
+	return OpenTransport(ctx, path, t)
+}
+
+// OpenTransport loads the plugin at path using the given transport.
+func OpenTransport(ctx context.Context, path string, t Transport) (*Plugin, error) {
+	switch t {
+	case TransportRPC:
+		return openRPC(ctx, path)
+	default:
+		return openNative(path)
+	}
+}
+
+// LookupPlugin extracts the typed Plugin symbol from an opened shared object.
+func LookupPlugin(p *plugin.Plugin) (typing.Plugin, error) {
+	sym, err := p.Lookup("Plugin")
+	if err != nil || sym == nil {
+		return nil, ErrNoPluginSymbol
+	}
+	// Symbol can be the interface value directly, or a pointer to it.
+	switch v := sym.(type) {
+	case typing.Plugin:
+		return v, nil
+	case *typing.Plugin:
+		if v != nil && *v != nil {
+			return *v, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unexpected type %T, expected typing.Plugin", ErrNoPluginSymbol, sym)
+}
+
+func openNative(path string) (*Plugin, error) {
+	// plugin.Open's own errors for toolchain or module mismatches are cryptic
+	// and cannot be retried in the same process; check up front.
+	if err := CheckABI(path); err != nil {
+		return nil, err
+	}
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin file: %w", err)
+	}
+	impl, err := LookupPlugin(p)
+	if err != nil {
+		return nil, err
+	}
+	hp := Wrap(impl)
+	// Native plugins stay loaded for the life of the process.
+	hp.Path, hp.Transport, hp.close = path, TransportNative, nil
+	return hp, nil
+}
+
+// Wrap returns a Plugin for an implementation that is already loaded, such
+// as an in-process plugin or a client for a remote one. Its optional
+// interfaces are detected with type assertions; clients that implement all
+// of them report what the remote side supports with a Capabilities method.
+// Close calls impl's Close method, if it has one.
+func Wrap(impl typing.Plugin) *Plugin {
+	hp := &Plugin{Plugin: impl}
+	caps := pluginrpc.Capabilities{}
+	_, caps.Authenticator = impl.(typing.Authenticator)
+	_, caps.Tester = impl.(typing.Tester)
+	if c, ok := impl.(interface{ Capabilities() pluginrpc.Capabilities }); ok {
+		remote := c.Capabilities()
+		caps.Authenticator = caps.Authenticator && remote.Authenticator
+		caps.Tester = caps.Tester && remote.Tester
+	}
+	if caps.Authenticator {
+		hp.auth = impl.(typing.Authenticator)
+	}
+	if caps.Tester {
+		hp.tester = impl.(typing.Tester)
+	}
+	if c, ok := impl.(interface{ Close() error }); ok {
+		hp.close = c.Close
+	}
+	return hp
+}
+
+func openRPC(ctx context.Context, path string) (*Plugin, error) {
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin process: %w", err)
+	}
+	client, err := pluginrpc.NewClient(pluginrpc.NewConn(stdout, stdin))
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	hp := Wrap(client)
+	hp.Path, hp.Transport = path, TransportRPC
+	// Closing the connection tells the plugin to exit; give it a moment before killing it.
+	hp.close = func() error {
+		_ = client.Close()
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(2 * time.Second):
+			_ = cmd.Process.Kill()
+			return <-done
+		}
+	}
+	return hp, nil
+}
@@ -0,0 +1,22 @@
+package host
+
+import "testing"
+
+func TestTransportFromString(t *testing.T) {
+	t.Run("should parse buildable runtimes", func(t *testing.T) {
+		cases := map[string]Transport{"": TransportNative, "native": TransportNative, " RPC ": TransportRPC}
+		for in, want := range cases {
+			if got, err := TransportFromString(in); err != nil || got != want {
+				t.Errorf("Expected %q for %q, got %q, %v", want, in, got, err)
+			}
+		}
+	})
+
+	t.Run("should reject unknown runtimes", func(t *testing.T) {
+		for _, in := range []string{"prc", "grpc", "so"} {
+			if _, err := TransportFromString(in); err == nil {
+				t.Errorf("Expected an error for %q", in)
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package host
+
+import (
+	"fmt"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// ValidateLedger checks that entry directions and currencies in l are known
+// and consistent. Malformed amounts already fail when the ledger is decoded.
+// It reports the first problem found.
+func ValidateLedger(l *models.Ledger) error {
+	if l == nil {
+		return nil
+	}
+	for _, e := range l.Entries {
+		if err := validateEntry(e); err != nil {
+			return fmt.Errorf("entry %d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+func validateEntry(e models.LedgerEntry) error {
+	if !e.Direction.Valid() {
+		return fmt.Errorf("unknown direction %q", e.Direction)
+	}
+	if e.Currency != "" {
+		if _, err := e.Amount.WithCurrency(e.Currency); err != nil {
+			return err
+		}
+		if c := e.Amount.Currency(); c != "" && c != e.Currency {
+			return fmt.Errorf("%w: amount is in %s, entry currency is %s", models.ErrCurrencyMismatch, c, e.Currency)
+		}
+	}
+	return nil
+}
+
+// ValidateLedgerDates checks the entries of a Ledger page returned for
+// params: every entry has a valid date within StartDate..EndDate, and dates
+// ascend, or descend when params.SortDescending is set. It reports the first
+// problem found.
+func ValidateLedgerDates(l *models.Ledger, params models.LedgerQueryParams) error {
+	if l == nil {
+		return nil
+	}
+	start, end, err := params.ParseDateRange()
+	if err != nil {
+		return err
+	}
+	var prev models.Date
+	for i, e := range l.Entries {
+		d, err := e.ParseDate()
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", e.ID, err)
+		}
+		if d.IsZero() {
+			return fmt.Errorf("entry %d has no date", e.ID)
+		}
+		if !start.IsZero() && d.Before(start) || !end.IsZero() && d.After(end) {
+			return fmt.Errorf("entry %d dated %s is outside the requested range %s..%s", e.ID, d, start, end)
+		}
+		if c := d.Compare(prev); i > 0 && (c < 0 && !params.SortDescending || c > 0 && params.SortDescending) {
+			order := "ascending"
+			if params.SortDescending {
+				order = "descending"
+			}
+			return fmt.Errorf("entry %d dated %s follows an entry dated %s; entries must be in %s date order", e.ID, d, prev, order)
+		}
+		prev = d
+	}
+	return nil
+}
+
+// ComputeBalances fills in the running Balance of every entry and the
+// ledger's ClosingBalance, starting from OpeningBalance and adding each
+// entry's Signed amount. Balances the plugin supplied are kept and the
+// running total carries on from them. l must hold the complete, unfiltered
+// ledger (see AllLedger) in ascending date order; FillBalances only calls it
+// when that holds.
+func ComputeBalances(l *models.Ledger) error {
+	if l == nil {
+		return nil
+	}
+	balance := l.OpeningBalance
+	for i := range l.Entries {
+		e := &l.Entries[i]
+		if e.Balance != nil {
+			balance = *e.Balance
+			continue
+		}
+		next, err := balance.Add(e.Signed())
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", e.ID, err)
+		}
+		balance = next
+		e.Balance = &next
+	}
+	if l.ClosingBalance == nil {
+		l.ClosingBalance = &balance
+	}
+	return nil
+}
+
+// FillBalances calls ComputeBalances when l is the whole ledger for params
+// in ascending date order: fetched without a cursor, page or limit, with no
+// next page, no DocTypes filter and entries whose dates ascend, as AllLedger
+// returns it for unfiltered params. Other results are left as the plugin
+// returned them, as their running balance depends on entries the host has
+// not seen.
+func FillBalances(l *models.Ledger, params models.LedgerQueryParams) error {
+	if l == nil || params.Cursor != "" || params.Page > 1 || params.Limit > 0 || params.SortDescending || len(params.DocTypes) > 0 {
+		return nil
+	}
+	if l.NextCursor != nil && *l.NextCursor != "" || !ascending(l.Entries) {
+		return nil
+	}
+	return ComputeBalances(l)
+}
+
+// ascending reports whether every entry has a valid date and none is dated
+// before the one preceding it.
+func ascending(entries []models.LedgerEntry) bool {
+	var prev models.Date
+	for _, e := range entries {
+		d, err := e.ParseDate()
+		if err != nil || d.IsZero() || d.Before(prev) {
+			return false
+		}
+		prev = d
+	}
+	return true
+}
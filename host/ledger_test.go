@@ -0,0 +1,165 @@
+package host
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func TestValidateLedger(t *testing.T) {
+	t.Run("should accept well-formed amounts", func(t *testing.T) {
+		l := &models.Ledger{OpeningBalance: models.MustParseMoney("10.00 EUR"), Entries: []models.LedgerEntry{{ID: 1, Amount: models.MustParseMoney("-2.50")}}}
+		if err := ValidateLedger(l); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should leave unparseable amounts to decoding", func(t *testing.T) {
+		var l models.Ledger
+		err := json.Unmarshal([]byte(`{"opening_balance":"0","entries":[{"id":4,"amount":"$1,200"}]}`), &l)
+		if err == nil || !strings.Contains(err.Error(), "$1,200") {
+			t.Errorf("Expected a decoding error naming the amount, got %v", err)
+		}
+	})
+
+	t.Run("should flag unknown directions and conflicting currencies", func(t *testing.T) {
+		for _, e := range []models.LedgerEntry{
+			{ID: 1, Amount: models.MustParseMoney("1"), Direction: "in"},
+			{ID: 2, Amount: models.MustParseMoney("1 EUR"), Currency: "USD"},
+			{ID: 3, Amount: models.MustParseMoney("1"), Currency: "usd"},
+		} {
+			if err := ValidateLedger(&models.Ledger{Entries: []models.LedgerEntry{e}}); err == nil {
+				t.Errorf("Expected an error for %+v", e)
+			}
+		}
+	})
+}
+
+func TestComputeBalances(t *testing.T) {
+	t.Run("should compute running and closing balances", func(t *testing.T) {
+		l := &models.Ledger{OpeningBalance: models.MustParseMoney("100.00"), Entries: []models.LedgerEntry{
+			{ID: 1, DocType: models.DocTypeInvoice, Amount: models.MustParseMoney("250.00")},
+			{ID: 2, DocType: models.DocTypePayment, Amount: models.MustParseMoney("150.00"), Direction: models.DirectionCredit},
+			{ID: 3, DocType: models.DocTypeCreditNote, Amount: models.MustParseMoney("-25.5")},
+		}}
+		if err := ComputeBalances(l); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var got []string
+		for _, e := range l.Entries {
+			got = append(got, e.Balance.String())
+		}
+		if strings.Join(got, ",") != "350.00,200.00,174.50" || l.ClosingBalance.String() != "174.50" {
+			t.Errorf("Expected 350.00,200.00,174.50 closing 174.50, got %v closing %s", got, l.ClosingBalance)
+		}
+	})
+
+	t.Run("should keep balances supplied by the plugin", func(t *testing.T) {
+		supplied, closing := models.MustParseMoney("5.00"), models.MustParseMoney("9.99")
+		l := &models.Ledger{Entries: []models.LedgerEntry{
+			{ID: 1, Amount: models.MustParseMoney("1.00"), Balance: &supplied},
+			{ID: 2, Amount: models.MustParseMoney("2.00")},
+		}, ClosingBalance: &closing}
+		if err := ComputeBalances(l); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if l.Entries[0].Balance != &supplied || l.Entries[1].Balance.String() != "7.00" || l.ClosingBalance != &closing {
+			t.Errorf("Expected supplied balances kept and 7.00 after entry 2, got %+v", l)
+		}
+	})
+
+	t.Run("should apply the entry currency", func(t *testing.T) {
+		l := &models.Ledger{OpeningBalance: models.MustParseMoney("1 EUR"), Entries: []models.LedgerEntry{
+			{ID: 1, Amount: models.MustParseMoney("2"), Currency: "USD"},
+		}}
+		if err := ComputeBalances(l); !errors.Is(err, models.ErrCurrencyMismatch) {
+			t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+}
+
+func TestFillBalances(t *testing.T) {
+	ledger := func(next string, dates ...string) *models.Ledger {
+		l := &models.Ledger{OpeningBalance: models.MustParseMoney("1.00")}
+		for i, d := range dates {
+			l.Entries = append(l.Entries, models.LedgerEntry{ID: int64(i + 1), Date: d, Amount: models.MustParseMoney("2.00")})
+		}
+		if next != "" {
+			l.NextCursor = &next
+		}
+		return l
+	}
+
+	t.Run("should fill balances of a complete ledger", func(t *testing.T) {
+		l := ledger("", "2025-01-01", "2025-01-01", "2025-01-02")
+		if err := FillBalances(l, models.LedgerQueryParams{}); err != nil || l.Entries[2].Balance.String() != "7.00" || l.ClosingBalance.String() != "7.00" {
+			t.Errorf("Expected balances up to 7.00, got %+v, %v", l, err)
+		}
+	})
+
+	t.Run("should leave partial, filtered and unordered ledgers alone", func(t *testing.T) {
+		cases := map[string]struct {
+			l      *models.Ledger
+			params models.LedgerQueryParams
+		}{
+			"next page":  {ledger("abc", "2025-01-01"), models.LedgerQueryParams{}},
+			"later page": {ledger("", "2025-01-01"), models.LedgerQueryParams{CommonParams: models.CommonParams{Cursor: "abc"}}},
+			"page 2":     {ledger("", "2025-01-01"), models.LedgerQueryParams{CommonParams: models.CommonParams{Page: 2}}},
+			"limit":      {ledger("", "2025-01-01"), models.LedgerQueryParams{CommonParams: models.CommonParams{Limit: 1}}},
+			"descending": {ledger("", "2025-01-01"), models.LedgerQueryParams{CommonParams: models.CommonParams{SortDescending: true}}},
+			"doc types":  {ledger("", "2025-01-01"), models.LedgerQueryParams{DocTypes: []models.DocType{models.DocTypeInvoice}}},
+			"unordered":  {ledger("", "2025-01-02", "2025-01-01"), models.LedgerQueryParams{}},
+			"no date":    {ledger("", ""), models.LedgerQueryParams{}},
+		}
+		for name, c := range cases {
+			if err := FillBalances(c.l, c.params); err != nil || c.l.Entries[0].Balance != nil || c.l.ClosingBalance != nil {
+				t.Errorf("%s: Expected no balances, got %+v, %v", name, c.l, err)
+			}
+		}
+	})
+}
+
+func TestValidateLedgerDates(t *testing.T) {
+	entries := func(dates ...string) *models.Ledger {
+		l := &models.Ledger{}
+		for i, d := range dates {
+			l.Entries = append(l.Entries, models.LedgerEntry{ID: int64(i + 1), Date: d})
+		}
+		return l
+	}
+	params := models.LedgerQueryParams{StartDate: "2025-01-01", EndDate: "2025-01-31"}
+
+	t.Run("should accept ascending entries within the range", func(t *testing.T) {
+		if err := ValidateLedgerDates(entries("2025-01-01", "2025-01-01", "2025-01-31"), params); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should follow SortDescending", func(t *testing.T) {
+		desc := params
+		desc.SortDescending = true
+		if err := ValidateLedgerDates(entries("2025-01-20", "2025-01-02"), desc); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := ValidateLedgerDates(entries("2025-01-02", "2025-01-20"), desc); err == nil || !strings.Contains(err.Error(), "descending") {
+			t.Errorf("Expected a descending order error, got %v", err)
+		}
+	})
+
+	t.Run("should flag bad dates", func(t *testing.T) {
+		cases := map[string]*models.Ledger{
+			"outside":   entries("2025-01-05", "2025-02-01"),
+			"ascending": entries("2025-01-05", "2025-01-04"),
+			"invalid":   entries("05/01/2025"),
+			"no date":   entries(""),
+		}
+		for want, l := range cases {
+			if err := ValidateLedgerDates(l, params); err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected an error mentioning %q, got %v", want, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,400 @@
+package host
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// RefreshSkew is how long before its expiry a token is refreshed.
+const RefreshSkew = time.Minute
+
+// CallbackPath is the path of the loopback redirect URI.
+const CallbackPath = "/callback"
+
+var (
+	// ErrNoOAuth2Config is returned for plugins whose metadata lacks OAuth2.
+	ErrNoOAuth2Config = errors.New("plugin metadata has no oauth2 configuration")
+	// ErrTokenExpired is returned when a token has expired and cannot be refreshed.
+	ErrTokenExpired = errors.New("oauth2 token expired and has no refresh token")
+)
+
+// OAuth2Error is an error response from the provider (RFC 6749 section 5.2)
+// or the authorization redirect.
+type OAuth2Error struct {
+	Code        string
+	Description string
+	StatusCode  int
+}
+
+func (e *OAuth2Error) Error() string {
+	msg := "oauth2: " + e.Code
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (HTTP %d)", e.StatusCode)
+	}
+	return msg
+}
+
+// OAuth2Token is a token set issued by the provider. The client credentials
+// are kept with it so it can be refreshed unattended.
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresIn    int64     `json:"expires_in,omitempty"`
+	ObtainedAt   time.Time `json:"obtained_at"`
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+}
+
+// Expiry returns when the token expires; zero when the provider gave no expires_in.
+func (t *OAuth2Token) Expiry() time.Time {
+	if t.ExpiresIn <= 0 {
+		return time.Time{}
+	}
+	return t.ObtainedAt.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// Expired reports whether the token expires within skew of now.
+func (t *OAuth2Token) Expired(now time.Time, skew time.Duration) bool {
+	exp := t.Expiry()
+	return !exp.IsZero() && !now.Add(skew).Before(exp)
+}
+
+// Credentials returns the token as AuthCredentials for Contacts and Ledger:
+// access_token, token_type, refresh_token, expires_in and expires_at (RFC 3339).
+func (t *OAuth2Token) Credentials() models.AuthCredentials {
+	creds := models.AuthCredentials{"access_token": t.AccessToken}
+	if t.TokenType != "" {
+		creds["token_type"] = t.TokenType
+	}
+	if t.RefreshToken != "" {
+		creds["refresh_token"] = t.RefreshToken
+	}
+	if exp := t.Expiry(); !exp.IsZero() {
+		creds["expires_in"] = strconv.FormatInt(t.ExpiresIn, 10)
+		creds["expires_at"] = exp.UTC().Format(time.RFC3339)
+	}
+	return creds
+}
+
+// OAuth2Client talks to the provider described by Config.
+type OAuth2Client struct {
+	Config       *models.OAuth2Config
+	ClientID     string
+	ClientSecret string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewOAuth2Client returns a client for cfg. clientID falls back to cfg.ClientID.
+func NewOAuth2Client(cfg *models.OAuth2Config, clientID, clientSecret string) (*OAuth2Client, error) {
+	if cfg == nil || cfg.AuthorizeURL == "" || cfg.TokenURL == "" {
+		return nil, ErrNoOAuth2Config
+	}
+	if clientID == "" {
+		clientID = cfg.ClientID
+	}
+	if clientID == "" {
+		return nil, errors.New("oauth2: a client ID is required")
+	}
+	return &OAuth2Client{Config: cfg, ClientID: clientID, ClientSecret: clientSecret}, nil
+}
+
+// NewPKCE returns a random code verifier and its S256 challenge (RFC 7636).
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// AuthCodeURL builds the authorization URL the user opens in a browser.
+func (c *OAuth2Client) AuthCodeURL(redirectURI, state, challenge string, scopes []string) (string, error) {
+	u, err := url.Parse(c.Config.AuthorizeURL)
+	if err != nil {
+		return "", fmt.Errorf("oauth2: invalid authorize_url: %w", err)
+	}
+	q := u.Query()
+	for k, v := range c.Config.AuthURLParams {
+		q.Set(k, v)
+	}
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+	if len(scopes) == 0 {
+		scopes = c.Config.Scopes
+	}
+	if len(scopes) > 0 {
+		q.Set("scope", strings.Join(scopes, " "))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange trades an authorization code for a token.
+func (c *OAuth2Client) Exchange(ctx context.Context, code, redirectURI, verifier string) (*OAuth2Token, error) {
+	return c.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh obtains a new access token. The refresh token is kept when the
+// provider does not rotate it.
+func (c *OAuth2Client) Refresh(ctx context.Context, refreshToken string) (*OAuth2Token, error) {
+	tok, err := c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = refreshToken
+	}
+	return tok, nil
+}
+
+func (c *OAuth2Client) token(ctx context.Context, form url.Values) (*OAuth2Token, error) {
+	form.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	tok, err := parseTokenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	tok.ClientID, tok.ClientSecret = c.ClientID, c.ClientSecret
+	return tok, nil
+}
+
+// parseTokenResponse accepts JSON and form-encoded token responses.
+func parseTokenResponse(resp *http.Response) (*OAuth2Token, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: reading token response: %w", err)
+	}
+	fields := map[string]any{}
+	if ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); ct == "application/x-www-form-urlencoded" || ct == "text/plain" {
+		vals, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("oauth2: invalid token response: %w", err)
+		}
+		for k := range vals {
+			fields[k] = vals.Get(k)
+		}
+	} else if err := json.Unmarshal(body, &fields); err != nil {
+		if resp.StatusCode/100 != 2 {
+			return nil, &OAuth2Error{Code: http.StatusText(resp.StatusCode), StatusCode: resp.StatusCode}
+		}
+		return nil, fmt.Errorf("oauth2: invalid token response: %w", err)
+	}
+	str := func(k string) string {
+		switch v := fields[k].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return ""
+	}
+	if code := str("error"); code != "" || resp.StatusCode/100 != 2 {
+		if code == "" {
+			code = http.StatusText(resp.StatusCode)
+		}
+		return nil, &OAuth2Error{Code: code, Description: str("error_description"), StatusCode: resp.StatusCode}
+	}
+	tok := &OAuth2Token{
+		AccessToken:  str("access_token"),
+		RefreshToken: str("refresh_token"),
+		TokenType:    str("token_type"),
+		Scope:        str("scope"),
+		ObtainedAt:   time.Now().UTC(),
+	}
+	if tok.AccessToken == "" {
+		return nil, errors.New("oauth2: token response has no access_token")
+	}
+	if s := str("expires_in"); s != "" {
+		if tok.ExpiresIn, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("oauth2: invalid expires_in %q", s)
+		}
+	}
+	return tok, nil
+}
+
+// AuthorizeOptions configures AuthorizeOAuth2.
+type AuthorizeOptions struct {
+	// Scopes override the scopes from the plugin metadata.
+	Scopes []string
+	// Port of the loopback redirect server; 0 picks a free port.
+	Port int
+	// OpenURL is called with the authorization URL, e.g. to open a browser.
+	OpenURL func(string) error
+}
+
+// AuthorizeOAuth2 runs the authorization code flow with PKCE: it serves
+// http://127.0.0.1:<port>/callback, hands the authorization URL to
+// opts.OpenURL and exchanges the code the provider redirects back with.
+func AuthorizeOAuth2(ctx context.Context, c *OAuth2Client, opts AuthorizeOptions) (*OAuth2Token, error) {
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(opts.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: cannot listen for the redirect: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", ln.Addr().String(), CallbackPath)
+
+	type result struct {
+		code string
+		err  error
+	}
+	done := make(chan result, 1)
+	finish := func(r result) {
+		select {
+		case done <- r:
+		default:
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(CallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("state") != state:
+			http.Error(w, "Invalid state parameter.", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			err := &OAuth2Error{Code: q.Get("error"), Description: q.Get("error_description")}
+			http.Error(w, "Authorization failed: "+err.Error(), http.StatusBadRequest)
+			finish(result{err: err})
+			return
+		case q.Get("code") == "":
+			http.Error(w, "Missing authorization code.", http.StatusBadRequest)
+			finish(result{err: errors.New("oauth2: redirect without an authorization code")})
+			return
+		}
+		fmt.Fprintln(w, "Authorization complete. You can close this window.")
+		finish(result{code: q.Get("code")})
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	authURL, err := c.AuthCodeURL(redirectURI, state, challenge, opts.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if opts.OpenURL != nil {
+		if err := opts.OpenURL(authURL); err != nil {
+			return nil, err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("oauth2: waiting for the redirect: %w", ctx.Err())
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return c.Exchange(ctx, r.code, redirectURI, verifier)
+	}
+}
+
+// FreshToken loads the token stored under name and, when it is about to
+// expire, refreshes it and saves the result.
+func FreshToken(ctx context.Context, store TokenStore, name string, cfg *models.OAuth2Config) (*OAuth2Token, error) {
+	tok, err := store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	if !tok.Expired(time.Now(), RefreshSkew) {
+		return tok, nil
+	}
+	if tok.RefreshToken == "" {
+		return nil, ErrTokenExpired
+	}
+	c, err := NewOAuth2Client(cfg, tok.ClientID, tok.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	fresh, err := c.Refresh(ctx, tok.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing the %s token: %w", name, err)
+	}
+	if err := store.Save(name, fresh); err != nil {
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// OAuth2Credentials returns the stored, refreshed-if-needed token of an
+// AuthTypeOAuth2 plugin merged under auth, so explicitly passed credentials
+// win. Other plugins get auth back unchanged.
+func OAuth2Credentials(ctx context.Context, store TokenStore, name string, meta *models.MetaData, auth models.AuthCredentials) (models.AuthCredentials, error) {
+	if meta == nil || meta.AuthType != models.AuthTypeOAuth2 {
+		return auth, nil
+	}
+	tok, err := FreshToken(ctx, store, name, meta.OAuth2)
+	if err != nil {
+		return auth, err
+	}
+	creds := tok.Credentials()
+	maps.Copy(creds, auth)
+	return creds, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
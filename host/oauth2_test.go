@@ -0,0 +1,246 @@
+package host
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// fakeProvider implements the authorize and token endpoints of an OAuth2
+// provider that requires PKCE.
+type fakeProvider struct {
+	mu         sync.Mutex
+	challenges map[string]string // code -> challenge
+	refreshes  int
+	scope      string
+}
+
+func newFakeProvider(t *testing.T) (*fakeProvider, *models.OAuth2Config) {
+	p := &fakeProvider{challenges: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "cli" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		p.mu.Lock()
+		p.challenges["code-1"] = q.Get("code_challenge")
+		p.scope = q.Get("scope")
+		p.mu.Unlock()
+		redirect, _ := url.Parse(q.Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"code": {"code-1"}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			p.mu.Lock()
+			challenge := p.challenges[r.Form.Get("code")]
+			p.mu.Unlock()
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if challenge == "" || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"PKCE verification failed"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"at-1","refresh_token":"rt-1","token_type":"Bearer","expires_in":3600}`)
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != "rt-1" || r.Form.Get("client_secret") != "sec" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+			p.mu.Lock()
+			p.refreshes++
+			p.mu.Unlock()
+			fmt.Fprint(w, `{"access_token":"at-2","token_type":"Bearer","expires_in":"7200"}`)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return p, &models.OAuth2Config{AuthorizeURL: srv.URL + "/authorize", TokenURL: srv.URL + "/token", Scopes: []string{"contacts.read", "ledger.read"}}
+}
+
+func TestAuthorizeOAuth2(t *testing.T) {
+	provider, cfg := newFakeProvider(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("should complete the code flow with PKCE", func(t *testing.T) {
+		c, err := NewOAuth2Client(cfg, "cli", "sec")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tok, err := AuthorizeOAuth2(ctx, c, AuthorizeOptions{OpenURL: func(u string) error {
+			// Stand in for the browser: follow the provider's redirect to the loopback server.
+			resp, err := http.Get(u)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if tok.AccessToken != "at-1" || tok.RefreshToken != "rt-1" || tok.ExpiresIn != 3600 || tok.ClientSecret != "sec" {
+			t.Errorf("Unexpected token %+v", tok)
+		}
+		if provider.scope != "contacts.read ledger.read" {
+			t.Errorf("Expected metadata scopes, got %q", provider.scope)
+		}
+	})
+
+	t.Run("should report provider errors from the redirect", func(t *testing.T) {
+		c, _ := NewOAuth2Client(cfg, "cli", "")
+		_, err := AuthorizeOAuth2(ctx, c, AuthorizeOptions{OpenURL: func(u string) error {
+			q, _ := url.Parse(u)
+			cb := q.Query().Get("redirect_uri") + "?error=access_denied&state=" + q.Query().Get("state")
+			go func() {
+				if resp, err := http.Get(cb); err == nil {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		}})
+		var oe *OAuth2Error
+		if !errors.As(err, &oe) || oe.Code != "access_denied" {
+			t.Errorf("Expected access_denied, got %v", err)
+		}
+	})
+
+	t.Run("should require a client ID and provider URLs", func(t *testing.T) {
+		if _, err := NewOAuth2Client(cfg, "", ""); err == nil {
+			t.Errorf("Expected error without client ID")
+		}
+		if _, err := NewOAuth2Client(nil, "cli", ""); !errors.Is(err, ErrNoOAuth2Config) {
+			t.Errorf("Expected ErrNoOAuth2Config, got %v", err)
+		}
+	})
+}
+
+func TestFreshToken(t *testing.T) {
+	provider, cfg := newFakeProvider(t)
+	ctx := context.Background()
+	store := FileTokenStore{Dir: t.TempDir()}
+
+	t.Run("should report a missing token", func(t *testing.T) {
+		if _, err := FreshToken(ctx, store, "crm", cfg); !errors.Is(err, ErrNoToken) {
+			t.Errorf("Expected ErrNoToken, got %v", err)
+		}
+	})
+
+	t.Run("should return a valid token as stored", func(t *testing.T) {
+		valid := &OAuth2Token{AccessToken: "at-1", RefreshToken: "rt-1", ExpiresIn: 3600, ObtainedAt: time.Now(), ClientID: "cli", ClientSecret: "sec"}
+		if err := store.Save("crm", valid); err != nil {
+			t.Fatal(err)
+		}
+		tok, err := FreshToken(ctx, store, "crm", cfg)
+		if err != nil || tok.AccessToken != "at-1" || provider.refreshes != 0 {
+			t.Errorf("Expected stored token without refresh, got %+v, %v", tok, err)
+		}
+	})
+
+	t.Run("should refresh and save an expiring token", func(t *testing.T) {
+		expiring := &OAuth2Token{AccessToken: "at-1", RefreshToken: "rt-1", ExpiresIn: 3600, ObtainedAt: time.Now().Add(-time.Hour + 30*time.Second), ClientID: "cli", ClientSecret: "sec"}
+		if err := store.Save("crm", expiring); err != nil {
+			t.Fatal(err)
+		}
+		tok, err := FreshToken(ctx, store, "crm", cfg)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if tok.AccessToken != "at-2" || tok.RefreshToken != "rt-1" || tok.ExpiresIn != 7200 || provider.refreshes != 1 {
+			t.Errorf("Expected refreshed token keeping rt-1, got %+v", tok)
+		}
+		saved, _ := store.Load("crm")
+		if saved.AccessToken != "at-2" {
+			t.Errorf("Expected refreshed token to be saved, got %q", saved.AccessToken)
+		}
+	})
+
+	t.Run("should fail for an expired token without refresh token", func(t *testing.T) {
+		if err := store.Save("old", &OAuth2Token{AccessToken: "x", ExpiresIn: 60, ObtainedAt: time.Now().Add(-time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FreshToken(ctx, store, "old", cfg); !errors.Is(err, ErrTokenExpired) {
+			t.Errorf("Expected ErrTokenExpired, got %v", err)
+		}
+	})
+
+	t.Run("should merge the token under explicit credentials for oauth2 plugins", func(t *testing.T) {
+		meta := &models.MetaData{AuthType: models.AuthTypeOAuth2, OAuth2: cfg}
+		creds, err := OAuth2Credentials(ctx, store, "crm", meta, models.AuthCredentials{"tenant": "acme", "token_type": "MAC"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if creds["access_token"] != "at-2" || creds["tenant"] != "acme" || creds["token_type"] != "MAC" || creds["expires_in"] != "7200" {
+			t.Errorf("Unexpected credentials %v", creds)
+		}
+		plain := &models.MetaData{AuthType: models.AuthTypeAPIKey}
+		if creds, _ := OAuth2Credentials(ctx, store, "crm", plain, nil); creds != nil {
+			t.Errorf("Expected non-oauth2 plugins to be left alone, got %v", creds)
+		}
+	})
+}
+
+func TestParseTokenResponse(t *testing.T) {
+	respond := func(status int, contentType, body string) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", contentType)
+		rec.WriteHeader(status)
+		rec.WriteString(body)
+		return rec.Result()
+	}
+
+	t.Run("should parse form-encoded responses", func(t *testing.T) {
+		tok, err := parseTokenResponse(respond(200, "application/x-www-form-urlencoded", "access_token=a&token_type=bearer&expires_in=60"))
+		if err != nil || tok.AccessToken != "a" || tok.ExpiresIn != 60 {
+			t.Errorf("Unexpected %+v, %v", tok, err)
+		}
+	})
+
+	t.Run("should return provider errors", func(t *testing.T) {
+		_, err := parseTokenResponse(respond(400, "application/json", `{"error":"invalid_client","error_description":"unknown client"}`))
+		var oe *OAuth2Error
+		if !errors.As(err, &oe) || oe.Code != "invalid_client" || !strings.Contains(err.Error(), "unknown client") {
+			t.Errorf("Expected invalid_client, got %v", err)
+		}
+		if _, err := parseTokenResponse(respond(502, "text/html", "<html>")); !errors.As(err, &oe) || oe.StatusCode != 502 {
+			t.Errorf("Expected HTTP 502 error, got %v", err)
+		}
+	})
+
+	t.Run("should require an access token", func(t *testing.T) {
+		if _, err := parseTokenResponse(respond(200, "application/json", `{"token_type":"bearer"}`)); err == nil {
+			t.Errorf("Expected error without access_token")
+		}
+	})
+}
+
+func TestTokenExpiry(t *testing.T) {
+	now := time.Now()
+	tok := &OAuth2Token{ExpiresIn: 120, ObtainedAt: now}
+	if tok.Expired(now, RefreshSkew) {
+		t.Errorf("Expected token with 2 minutes left to be valid")
+	}
+	if !tok.Expired(now.Add(90*time.Second), RefreshSkew) {
+		t.Errorf("Expected token within the refresh skew to count as expired")
+	}
+	if (&OAuth2Token{ObtainedAt: now}).Expired(now.Add(24*time.Hour), RefreshSkew) {
+		t.Errorf("Expected tokens without expires_in to never expire")
+	}
+}
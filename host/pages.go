@@ -0,0 +1,84 @@
+package host
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// DefaultMaxPages bounds AllContacts and AllLedger when maxPages is <= 0.
+const DefaultMaxPages = 1000
+
+// AllContacts calls Contacts repeatedly, following NextCursor until it is
+// exhausted, and returns every item in one page. Count and Total describe
+// the combined result.
+func (p *Plugin) AllContacts(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams, maxPages int) (*models.Contacts, error) {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	all := &models.Contacts{}
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		out, err := p.ContactsContext(ctx, auth, params)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		if out == nil {
+			return nil, fmt.Errorf("page %d: plugin returned no contacts", page)
+		}
+		all.Items = append(all.Items, out.Items...)
+		next, err := nextCursor(out.NextCursor, seen, page, maxPages)
+		if err != nil || next == "" {
+			all.Count, all.Total = len(all.Items), len(all.Items)
+			return all, err
+		}
+		params.Cursor = next
+	}
+}
+
+// AllLedger calls Ledger repeatedly, following NextCursor until it is
+// exhausted, and returns every entry in one page. CustomerName and
+// OpeningBalance are taken from the first page, ClosingBalance from the last.
+func (p *Plugin) AllLedger(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams, maxPages int) (*models.Ledger, error) {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	var all *models.Ledger
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		out, err := p.LedgerContext(ctx, auth, params)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+		if out == nil {
+			return nil, fmt.Errorf("page %d: plugin returned no ledger", page)
+		}
+		if all == nil {
+			all = &models.Ledger{CustomerName: out.CustomerName, OpeningBalance: out.OpeningBalance}
+		}
+		all.Entries = append(all.Entries, out.Entries...)
+		all.ClosingBalance = out.ClosingBalance
+		next, err := nextCursor(out.NextCursor, seen, page, maxPages)
+		if err != nil || next == "" {
+			return all, err
+		}
+		params.Cursor = next
+	}
+}
+
+// nextCursor returns the cursor for the next page, or "" when done. A cursor
+// seen before or running past maxPages is an error rather than an endless loop.
+func nextCursor(c *string, seen map[string]bool, page, maxPages int) (string, error) {
+	if c == nil || *c == "" {
+		return "", nil
+	}
+	if seen[*c] {
+		return "", fmt.Errorf("page %d: cursor %q was already returned", page, *c)
+	}
+	if page >= maxPages {
+		return "", fmt.Errorf("stopped after %d pages; more results remain", maxPages)
+	}
+	seen[*c] = true
+	return *c, nil
+}
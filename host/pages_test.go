@@ -0,0 +1,107 @@
+package host
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// pagedPlugin serves names two per page with numeric cursors; cursorFor
+// overrides the next cursor to simulate misbehaving plugins.
+type pagedPlugin struct {
+	names     []string
+	calls     int
+	cursorFor func(next int) *string
+}
+
+func (p *pagedPlugin) Meta() *models.MetaData { return &models.MetaData{ID: "paged"} }
+func (p *pagedPlugin) Health() string         { return "ok" }
+
+func (p *pagedPlugin) page(cursor string) (start, end int, next *string) {
+	p.calls++
+	start, _ = strconv.Atoi(cursor)
+	end = min(start+2, len(p.names))
+	if end < len(p.names) {
+		c := strconv.Itoa(end)
+		next = &c
+	}
+	if p.cursorFor != nil {
+		next = p.cursorFor(end)
+	}
+	return start, end, next
+}
+
+func (p *pagedPlugin) Contacts(_ models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	start, end, next := p.page(params.Cursor)
+	out := &models.Contacts{Total: len(p.names), NextCursor: next}
+	for i := start; i < end; i++ {
+		out.Items = append(out.Items, models.Contact{ID: strconv.Itoa(i), Name: p.names[i]})
+	}
+	out.Count = len(out.Items)
+	return out, nil
+}
+
+func (p *pagedPlugin) Ledger(_ models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	start, end, next := p.page(params.Cursor)
+	out := &models.Ledger{CustomerName: "Acme", OpeningBalance: models.MustParseMoney("10.00"), NextCursor: next}
+	for i := start; i < end; i++ {
+		out.Entries = append(out.Entries, models.LedgerEntry{ID: int64(i), DocType: models.DocTypeInvoice})
+	}
+	return out, nil
+}
+
+func TestAllPages(t *testing.T) {
+	ctx := context.Background()
+	names := []string{"a", "b", "c", "d", "e"}
+
+	t.Run("should merge every contacts page", func(t *testing.T) {
+		fake := &pagedPlugin{names: names}
+		out, err := (&Plugin{Plugin: fake}).AllContacts(ctx, nil, models.ContactQueryParams{}, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if fake.calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", fake.calls)
+		}
+		if out.Count != 5 || out.Total != 5 || len(out.Items) != 5 || out.NextCursor != nil {
+			t.Errorf("Expected 5 merged contacts without a cursor, got %+v", out)
+		}
+		if out.Items[4].Name != "e" {
+			t.Errorf("Expected last contact e, got %q", out.Items[4].Name)
+		}
+	})
+
+	t.Run("should merge every ledger page and keep the header", func(t *testing.T) {
+		fake := &pagedPlugin{names: names}
+		out, err := (&Plugin{Plugin: fake}).AllLedger(ctx, nil, models.LedgerQueryParams{}, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(out.Entries) != 5 || out.CustomerName != "Acme" || out.OpeningBalance.String() != "10.00" {
+			t.Errorf("Expected 5 entries for Acme, got %+v", out)
+		}
+	})
+
+	t.Run("should stop on a repeated cursor", func(t *testing.T) {
+		stuck := "2"
+		fake := &pagedPlugin{names: names, cursorFor: func(int) *string { return &stuck }}
+		_, err := (&Plugin{Plugin: fake}).AllContacts(ctx, nil, models.ContactQueryParams{}, 0)
+		if err == nil || !strings.Contains(err.Error(), "already returned") {
+			t.Errorf("Expected repeated cursor error, got %v", err)
+		}
+	})
+
+	t.Run("should stop after max pages", func(t *testing.T) {
+		fake := &pagedPlugin{names: names}
+		_, err := (&Plugin{Plugin: fake}).AllContacts(ctx, nil, models.ContactQueryParams{}, 2)
+		if err == nil || !strings.Contains(err.Error(), "stopped after 2 pages") {
+			t.Errorf("Expected max pages error, got %v", err)
+		}
+		if fake.calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", fake.calls)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package host
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoToken is returned by TokenStore.Load when nothing is stored for a plugin.
+var ErrNoToken = errors.New("no stored oauth2 token")
+
+// TokenStore persists OAuth2 tokens per plugin name.
+type TokenStore interface {
+	Load(name string) (*OAuth2Token, error)
+	Save(name string, tok *OAuth2Token) error
+	Delete(name string) error
+}
+
+// FileTokenStore keeps one JSON file per plugin in Dir, readable only by the
+// current user. Tokens and client secrets are stored in plaintext; the
+// uagplugin CLI keeps them in its encrypted store instead.
+type FileTokenStore struct {
+	Dir string
+}
+
+var _ TokenStore = FileTokenStore{}
+
+func (s FileTokenStore) path(name string) string {
+	return filepath.Join(s.Dir, name+".json")
+}
+
+func (s FileTokenStore) Load(name string) (*OAuth2Token, error) {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s (run 'uagplugin auth %s')", ErrNoToken, name, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	tok := &OAuth2Token{}
+	if err := json.Unmarshal(data, tok); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", s.path(name), err)
+	}
+	return tok, nil
+}
+
+func (s FileTokenStore) Save(name string, tok *OAuth2Token) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(tok, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path(name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(name))
+}
+
+func (s FileTokenStore) Delete(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
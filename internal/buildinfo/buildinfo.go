@@ -0,0 +1,186 @@
+// Package buildinfo records how a plugin artifact was built in a sidecar file
+// next to it, so builds can be reproduced and compared with the host.
+package buildinfo
+
+import (
+	gobuildinfo "debug/buildinfo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+)
+
+// Suffix is appended to an artifact path to name its sidecar file.
+const Suffix = ".buildinfo.json"
+
+// ModulePath is the module plugins build against.
+const ModulePath = "github.com/nikhiljohn10/uagplugin"
+
+// Module is a module that went into a build.
+type Module struct {
+	Path    string  `json:"path"`
+	Version string  `json:"version,omitempty"`
+	Sum     string  `json:"sum,omitempty"`
+	Replace *Module `json:"replace,omitempty"`
+}
+
+// String formats m like `go version -m`, e.g. "a v1.0.0 => ../a".
+func (m Module) String() string {
+	s := m.Path
+	if m.Version != "" {
+		s += " " + m.Version
+	}
+	if m.Replace != nil {
+		s += " => " + m.Replace.String()
+	}
+	return s
+}
+
+// LocalDir returns the directory m was replaced with, if it was built from a
+// local checkout rather than a module version.
+func (m Module) LocalDir() (string, bool) {
+	if m.Replace == nil {
+		return "", false
+	}
+	p := m.Replace.Path
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") {
+		return p, true
+	}
+	return "", false
+}
+
+// Info describes a plugin build.
+type Info struct {
+	GoVersion string `json:"go_version"`
+	GOOS      string `json:"goos,omitempty"`
+	GOARCH    string `json:"goarch,omitempty"`
+	// Tags holds the -tags build flag, comma separated.
+	Tags     string `json:"tags,omitempty"`
+	Trimpath bool   `json:"trimpath,omitempty"`
+	// Main is the plugin's own module.
+	Main Module `json:"main"`
+	// UAGPlugin is the uagplugin module the plugin was built against.
+	UAGPlugin *Module `json:"uagplugin,omitempty"`
+	// Deps lists every module linked into the artifact at its resolved version.
+	Deps []Module `json:"deps"`
+}
+
+// SidecarPath returns the sidecar file path for artifact.
+func SidecarPath(artifact string) string {
+	return artifact + Suffix
+}
+
+// FromArtifact reads the build information the Go toolchain embedded in a
+// compiled plugin (.so or executable).
+func FromArtifact(artifact string) (*Info, error) {
+	bi, err := gobuildinfo.ReadFile(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build info from %s: %w", artifact, err)
+	}
+	return FromBuildInfo(bi), nil
+}
+
+// FromBuildInfo converts build information embedded by the Go toolchain, such
+// as the running host's from debug.ReadBuildInfo.
+func FromBuildInfo(bi *debug.BuildInfo) *Info {
+	info := &Info{GoVersion: bi.GoVersion, Main: convert(&bi.Main)}
+	for _, d := range bi.Deps {
+		info.Deps = append(info.Deps, convert(d))
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "GOOS":
+			info.GOOS = s.Value
+		case "GOARCH":
+			info.GOARCH = s.Value
+		case "-tags":
+			info.Tags = s.Value
+		case "-trimpath":
+			info.Trimpath = s.Value == "true"
+		}
+	}
+	if info.Main.Path == ModulePath {
+		m := info.Main
+		info.UAGPlugin = &m
+	} else if m, ok := info.Dep(ModulePath); ok {
+		info.UAGPlugin = &m
+	}
+	return info
+}
+
+func convert(m *debug.Module) Module {
+	out := Module{Path: m.Path, Version: m.Version, Sum: m.Sum}
+	if m.Replace != nil {
+		r := convert(m.Replace)
+		out.Replace = &r
+	}
+	return out
+}
+
+// Replaces returns the dependencies that were replaced during the build.
+func (i *Info) Replaces() []Module {
+	var out []Module
+	for _, d := range i.Deps {
+		if d.Replace != nil {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Dep returns the dependency with the given module path.
+func (i *Info) Dep(path string) (Module, bool) {
+	for _, d := range i.Deps {
+		if d.Path == path {
+			return d, true
+		}
+	}
+	return Module{}, false
+}
+
+// Write stores info in the sidecar file of artifact.
+func Write(artifact string, info *Info) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(SidecarPath(artifact), append(data, '\n'), 0644)
+}
+
+// Read loads the sidecar file of artifact. It returns an error wrapping
+// os.ErrNotExist for artifacts built before sidecars were written.
+func Read(artifact string) (*Info, error) {
+	data, err := os.ReadFile(SidecarPath(artifact))
+	if err != nil {
+		return nil, err
+	}
+	var info Info
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("invalid build info %s: %w", SidecarPath(artifact), err)
+	}
+	return &info, nil
+}
+
+// Rename moves artifact and its sidecar, if any, to dst.
+func Rename(artifact, dst string) error {
+	if err := os.Rename(artifact, dst); err != nil {
+		return err
+	}
+	if err := os.Rename(SidecarPath(artifact), SidecarPath(dst)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Remove deletes artifact and its sidecar. Missing files are not an error.
+func Remove(artifact string) error {
+	for _, p := range []string{artifact, SidecarPath(artifact)} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package buildinfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+)
+
+func TestFromArtifact(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	info, err := FromArtifact(exe)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.GoVersion == "" {
+		t.Errorf("Expected a Go version")
+	}
+
+	t.Run("should reject files without build info", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plain.so")
+		if err := os.WriteFile(path, []byte("not a binary"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FromArtifact(path); err == nil {
+			t.Errorf("Expected error for a non-Go file")
+		}
+	})
+}
+
+func TestSidecar(t *testing.T) {
+	dir := t.TempDir()
+	artifact := filepath.Join(dir, "p.so")
+	if err := os.WriteFile(artifact, []byte("so"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info := &Info{
+		GoVersion: "go1.25.0",
+		Main:      Module{Path: "example.com/p", Version: "(devel)"},
+		Deps: []Module{
+			{Path: "example.com/a", Version: "v1.0.0", Sum: "h1:abc="},
+			{Path: "github.com/nikhiljohn10/uagplugin", Version: "v0.2.1", Replace: &Module{Path: "/src/uagplugin", Version: "(devel)"}},
+		},
+	}
+
+	t.Run("should round trip through the sidecar file", func(t *testing.T) {
+		if err := Write(artifact, info); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got, err := Read(artifact)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(got.Deps) != 2 || got.Deps[0].Sum != "h1:abc=" {
+			t.Errorf("Expected deps to round trip, got %+v", got.Deps)
+		}
+		replaces := got.Replaces()
+		if len(replaces) != 1 || replaces[0].String() != "github.com/nikhiljohn10/uagplugin v0.2.1 => /src/uagplugin (devel)" {
+			t.Errorf("Expected one replace, got %v", replaces)
+		}
+	})
+
+	t.Run("should move and remove the sidecar with the artifact", func(t *testing.T) {
+		moved := filepath.Join(dir, "moved.so")
+		if err := Rename(artifact, moved); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := Read(moved); err != nil {
+			t.Errorf("Expected sidecar to move, got %v", err)
+		}
+		if err := Remove(moved); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := Read(moved); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected sidecar to be removed, got %v", err)
+		}
+		if err := Remove(moved); err != nil {
+			t.Errorf("Expected removing a missing artifact to succeed, got %v", err)
+		}
+	})
+}
+
+func TestFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.25.1",
+		Main:      debug.Module{Path: "example.com/plugin", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: ModulePath, Version: "v0.2.1", Replace: &debug.Module{Path: "../uagplugin", Version: "(devel)"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "-tags", Value: "netgo"},
+			{Key: "-trimpath", Value: "true"},
+			{Key: "GOOS", Value: "linux"},
+			{Key: "GOARCH", Value: "arm64"},
+		},
+	}
+	info := FromBuildInfo(bi)
+	if info.GOOS != "linux" || info.GOARCH != "arm64" || info.Tags != "netgo" || !info.Trimpath {
+		t.Errorf("Expected build settings to be read, got %+v", info)
+	}
+	if info.UAGPlugin == nil {
+		t.Fatalf("Expected the uagplugin module to be found")
+	}
+	if dir, ok := info.UAGPlugin.LocalDir(); !ok || dir != "../uagplugin" {
+		t.Errorf("Expected local checkout ../uagplugin, got %q (%v)", dir, ok)
+	}
+
+	t.Run("should treat uagplugin itself as the module", func(t *testing.T) {
+		host := FromBuildInfo(&debug.BuildInfo{Main: debug.Module{Path: ModulePath, Version: "v0.3.0"}})
+		if host.UAGPlugin == nil || host.UAGPlugin.Version != "v0.3.0" {
+			t.Errorf("Expected main module v0.3.0, got %+v", host.UAGPlugin)
+		}
+		if _, ok := host.UAGPlugin.LocalDir(); ok {
+			t.Errorf("Expected a release, not a local checkout")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Package conformance checks that a plugin's data honours the contract:
+// pagination, counts, sorting, search and ledger filters.
+//
+// Each Rule is independent so callers can report them separately (the CLI
+// runner as FuncResults, testkit as subtests). Plugin responses are fetched
+// lazily and shared between rules through a Suite.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+// DefaultMaxPages bounds pagination walks so a plugin that never stops
+// returning a cursor cannot loop forever.
+const DefaultMaxPages = 100
+
+// Config controls the parameters used for conformance calls.
+type Config struct {
+	Auth          models.AuthCredentials
+	ContactParams models.ContactQueryParams
+	LedgerParams  models.LedgerQueryParams
+	// CallTimeout bounds every individual plugin call (0 = no limit).
+	CallTimeout time.Duration
+	// MaxPages bounds every pagination walk (0 = DefaultMaxPages).
+	MaxPages int
+}
+
+// Rule is a single named conformance check.
+type Rule struct {
+	Name  string
+	Check func(ctx context.Context, s *Suite) error
+}
+
+// SkipError reports that a rule could not be evaluated (e.g. no data).
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string { return "skipped: " + e.Reason }
+
+func skip(format string, args ...any) error {
+	return &SkipError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// SkipReason returns the reason when err marks a skipped rule.
+func SkipReason(err error) (string, bool) {
+	var se *SkipError
+	if errors.As(err, &se) {
+		return se.Reason, true
+	}
+	return "", false
+}
+
+// Rules lists every conformance rule in execution order.
+var Rules = []Rule{
+	{Name: "contacts.pagination", Check: checkContactsPagination},
+	{Name: "contacts.count", Check: checkContactsCount},
+	{Name: "contacts.total", Check: checkContactsTotal},
+	{Name: "contacts.sort", Check: checkContactsSort},
+	{Name: "contacts.search", Check: checkContactsSearch},
+	{Name: "contacts.search_ids", Check: checkContactsSearchIDs},
+	{Name: "ledger.pagination", Check: checkLedgerPagination},
+	{Name: "ledger.date_range", Check: checkLedgerDateRange},
+	{Name: "ledger.doc_types", Check: checkLedgerDocTypes},
+}
+
+// Suite runs rules against one plugin, caching shared responses.
+type Suite struct {
+	plugin typing.Plugin
+	cfg    Config
+
+	// mu guards the cached walks; a rule abandoned on timeout may still be
+	// filling them while the next rule starts.
+	mu          sync.Mutex
+	contacts    *contactWalk
+	contactsErr error
+	ledger      *ledgerWalk
+	ledgerErr   error
+}
+
+// NewSuite prepares a suite for p.
+func NewSuite(p typing.Plugin, cfg Config) *Suite {
+	if cfg.MaxPages <= 0 {
+		cfg.MaxPages = DefaultMaxPages
+	}
+	return &Suite{plugin: p, cfg: cfg}
+}
+
+// Run evaluates a single rule.
+func (s *Suite) Run(ctx context.Context, r Rule) error {
+	return r.Check(ctx, s)
+}
+
+func (s *Suite) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.CallTimeout > 0 {
+		return context.WithTimeout(ctx, s.cfg.CallTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (s *Suite) fetchContacts(ctx context.Context, params models.ContactQueryParams) (*models.Contacts, error) {
+	ctx, cancel := s.callContext(ctx)
+	defer cancel()
+	if cp, ok := s.plugin.(typing.ContextPlugin); ok {
+		return cp.ContactsContext(ctx, s.cfg.Auth, params)
+	}
+	return s.plugin.Contacts(s.cfg.Auth, params)
+}
+
+func (s *Suite) fetchLedger(ctx context.Context, params models.LedgerQueryParams) (*models.Ledger, error) {
+	ctx, cancel := s.callContext(ctx)
+	defer cancel()
+	if cp, ok := s.plugin.(typing.ContextPlugin); ok {
+		return cp.LedgerContext(ctx, s.cfg.Auth, params)
+	}
+	return s.plugin.Ledger(s.cfg.Auth, params)
+}
+
+// baseContactParams returns the configured params positioned at the first page.
+func (s *Suite) baseContactParams() models.ContactQueryParams {
+	p := s.cfg.ContactParams
+	p.Cursor = ""
+	p.Page = 0
+	return p
+}
+
+// baseLedgerParams returns the configured params positioned at the first page.
+func (s *Suite) baseLedgerParams() models.LedgerQueryParams {
+	p := s.cfg.LedgerParams
+	p.Cursor = ""
+	p.Page = 0
+	return p
+}
+
+// allContacts walks every contact page with the configured params (ascending).
+func (s *Suite) allContacts(ctx context.Context) (*contactWalk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.contacts == nil && s.contactsErr == nil {
+		p := s.baseContactParams()
+		p.SortDescending = false
+		s.contacts, s.contactsErr = s.walkContacts(ctx, p)
+	}
+	return s.contacts, s.contactsErr
+}
+
+// allLedger walks every ledger page with the configured params.
+func (s *Suite) allLedger(ctx context.Context) (*ledgerWalk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ledger == nil && s.ledgerErr == nil {
+		s.ledger, s.ledgerErr = s.walkLedger(ctx, s.baseLedgerParams())
+	}
+	return s.ledger, s.ledgerErr
+}
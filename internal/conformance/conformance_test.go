@@ -0,0 +1,142 @@
+package conformance
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/utils"
+)
+
+// memPlugin is an in-memory plugin that honours the full contract.
+type memPlugin struct {
+	contacts []models.Contact
+	entries  []models.LedgerEntry
+	// ignoreFilters makes Ledger return every entry regardless of params.
+	ignoreFilters bool
+	// ignoreSearch makes Contacts return every contact regardless of Search.
+	ignoreSearch bool
+}
+
+func newMemPlugin() *memPlugin {
+	p := &memPlugin{}
+	names := []string{"Alice Smith", "Bob Jones", "Carol White", "Dave Brown", "Eve Black"}
+	for i, n := range names {
+		id := strconv.Itoa(i + 1)
+		p.contacts = append(p.contacts, models.Contact{ID: id, Name: n, Email: strings.ToLower(strings.Fields(n)[0]) + "@example.com"})
+	}
+	docs := []models.DocType{models.DocTypeInvoice, models.DocTypePayment}
+	for i := 1; i <= 8; i++ {
+		p.entries = append(p.entries, models.LedgerEntry{
+			ID:      int64(i),
+			Date:    "2025-01-0" + strconv.Itoa(i),
+			DocType: docs[i%2],
+			Amount:  models.MustParseMoney("10.00"),
+		})
+	}
+	return p
+}
+
+func (p *memPlugin) Meta() *models.MetaData { return &models.MetaData{ID: "mem"} }
+func (p *memPlugin) Health() string         { return "ok" }
+
+func (p *memPlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	var out []models.Contact
+	for _, c := range p.contacts {
+		if len(params.SearchIDs) > 0 && !slices.Contains(params.SearchIDs, c.ID) {
+			continue
+		}
+		term := strings.ToLower(params.Search)
+		if !p.ignoreSearch && term != "" && !strings.Contains(strings.ToLower(c.Name), term) && !strings.Contains(strings.ToLower(c.CompanyName), term) {
+			continue
+		}
+		out = append(out, c)
+	}
+	utils.SortContacts(&out, params.SortDescending)
+	items, next := utils.PaginateCursor(out, params.Cursor, 2)
+	return &models.Contacts{Items: items, Count: len(items), Total: len(out), NextCursor: next}, nil
+}
+
+func (p *memPlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	var out []models.LedgerEntry
+	for _, e := range p.entries {
+		if !p.ignoreFilters {
+			if params.StartDate != "" && e.Date < params.StartDate {
+				continue
+			}
+			if params.EndDate != "" && e.Date > params.EndDate {
+				continue
+			}
+			if len(params.DocTypes) > 0 && !slices.Contains(params.DocTypes, e.DocType) {
+				continue
+			}
+		}
+		out = append(out, e)
+	}
+	items, next := utils.PaginateCursor(out, params.Cursor, 3)
+	return &models.Ledger{Entries: items, NextCursor: next}, nil
+}
+
+func TestRules(t *testing.T) {
+	t.Run("should pass for a conforming plugin", func(t *testing.T) {
+		s := NewSuite(newMemPlugin(), Config{})
+		for _, r := range Rules {
+			if err := s.Run(context.Background(), r); err != nil {
+				t.Errorf("%s: unexpected error: %v", r.Name, err)
+			}
+		}
+	})
+
+	t.Run("should flag ignored ledger filters", func(t *testing.T) {
+		p := newMemPlugin()
+		p.ignoreFilters = true
+		s := NewSuite(p, Config{})
+		for _, r := range Rules {
+			err := s.Run(context.Background(), r)
+			failing := r.Name == "ledger.date_range" || r.Name == "ledger.doc_types"
+			if failing && err == nil {
+				t.Errorf("%s: expected failure", r.Name)
+			}
+			if !failing && err != nil {
+				t.Errorf("%s: unexpected error: %v", r.Name, err)
+			}
+		}
+	})
+
+	t.Run("should accept search matches on any text field", func(t *testing.T) {
+		search := Rule{Name: "contacts.search", Check: checkContactsSearch}
+		p := newMemPlugin()
+		p.contacts[1].CompanyName = "Alice's Bakery"
+		if err := NewSuite(p, Config{}).Run(context.Background(), search); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		p.ignoreSearch = true
+		if err := NewSuite(p, Config{}).Run(context.Background(), search); err == nil || !strings.Contains(err.Error(), "none of its text fields") {
+			t.Errorf("Expected a non-matching contact error, got %v", err)
+		}
+	})
+
+	t.Run("should skip rules without data", func(t *testing.T) {
+		s := NewSuite(&memPlugin{}, Config{})
+		for _, r := range Rules {
+			if r.Name != "contacts.sort" && r.Name != "ledger.date_range" {
+				continue
+			}
+			if _, ok := SkipReason(s.Run(context.Background(), r)); !ok {
+				t.Errorf("%s: expected skip", r.Name)
+			}
+		}
+	})
+
+	t.Run("should detect duplicate IDs across pages", func(t *testing.T) {
+		p := newMemPlugin()
+		p.contacts[1].ID = p.contacts[0].ID
+		s := NewSuite(p, Config{})
+		if err := s.Run(context.Background(), Rules[0]); err == nil {
+			t.Error("Expected duplicate ID failure")
+		}
+	})
+}
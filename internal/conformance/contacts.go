@@ -0,0 +1,208 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// contactWalk is the result of following NextCursor until exhausted.
+type contactWalk struct {
+	pages []models.Contacts
+	items []models.Contact
+}
+
+func (s *Suite) walkContacts(ctx context.Context, params models.ContactQueryParams) (*contactWalk, error) {
+	w := &contactWalk{}
+	seenCursors := map[string]bool{}
+	for page := 0; ; page++ {
+		if page >= s.cfg.MaxPages {
+			return nil, fmt.Errorf("pagination did not finish within %d pages", s.cfg.MaxPages)
+		}
+		out, err := s.fetchContacts(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("Contacts (page %d): %w", page+1, err)
+		}
+		if out == nil {
+			return nil, fmt.Errorf("Contacts (page %d) returned nil", page+1)
+		}
+		w.pages = append(w.pages, *out)
+		w.items = append(w.items, out.Items...)
+		if out.NextCursor == nil || *out.NextCursor == "" {
+			return w, nil
+		}
+		if seenCursors[*out.NextCursor] {
+			return nil, fmt.Errorf("cursor %q returned twice", *out.NextCursor)
+		}
+		seenCursors[*out.NextCursor] = true
+		params.Cursor = *out.NextCursor
+	}
+}
+
+func checkContactsPagination(ctx context.Context, s *Suite) error {
+	w, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, c := range w.items {
+		if seen[c.ID] {
+			return fmt.Errorf("duplicate contact ID %q across pages", c.ID)
+		}
+		seen[c.ID] = true
+	}
+	if total := w.pages[0].Total; total > 0 && total != len(w.items) {
+		return fmt.Errorf("walked %d contacts across %d pages but Total is %d", len(w.items), len(w.pages), total)
+	}
+	return nil
+}
+
+func checkContactsCount(ctx context.Context, s *Suite) error {
+	w, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	for i, p := range w.pages {
+		if p.Count != len(p.Items) {
+			return fmt.Errorf("page %d: Count is %d but %d items returned", i+1, p.Count, len(p.Items))
+		}
+	}
+	return nil
+}
+
+func checkContactsTotal(ctx context.Context, s *Suite) error {
+	w, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	first := w.pages[0].Total
+	for i, p := range w.pages {
+		if p.Total != first {
+			return fmt.Errorf("page %d: Total is %d but page 1 reported %d", i+1, p.Total, first)
+		}
+		if p.Total < p.Count {
+			return fmt.Errorf("page %d: Total %d is less than Count %d", i+1, p.Total, p.Count)
+		}
+	}
+	return nil
+}
+
+func checkContactsSort(ctx context.Context, s *Suite) error {
+	asc, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(asc.items) < 2 {
+		return skip("need at least 2 contacts, got %d", len(asc.items))
+	}
+	for i := 1; i < len(asc.items); i++ {
+		if asc.items[i-1].Name > asc.items[i].Name {
+			return fmt.Errorf("ascending order broken at %d: %q before %q", i, asc.items[i-1].Name, asc.items[i].Name)
+		}
+	}
+	p := s.baseContactParams()
+	p.SortDescending = true
+	desc, err := s.walkContacts(ctx, p)
+	if err != nil {
+		return err
+	}
+	if len(desc.items) != len(asc.items) {
+		return fmt.Errorf("descending walk returned %d contacts, ascending %d", len(desc.items), len(asc.items))
+	}
+	for i := 1; i < len(desc.items); i++ {
+		if desc.items[i-1].Name < desc.items[i].Name {
+			return fmt.Errorf("SortDescending order broken at %d: %q before %q", i, desc.items[i-1].Name, desc.items[i].Name)
+		}
+	}
+	return nil
+}
+
+func checkContactsSearch(ctx context.Context, s *Suite) error {
+	all, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	var target *models.Contact
+	for i := range all.items {
+		if strings.TrimSpace(all.items[i].Name) != "" {
+			target = &all.items[i]
+			break
+		}
+	}
+	if target == nil {
+		return skip("no contact with a name to search for")
+	}
+	term := strings.ToLower(strings.Fields(target.Name)[0])
+	p := s.baseContactParams()
+	p.Search = term
+	got, err := s.walkContacts(ctx, p)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, c := range got.items {
+		if c.ID == target.ID {
+			found = true
+		}
+		if !slices.ContainsFunc(contactText(c), func(v string) bool { return strings.Contains(strings.ToLower(v), term) }) {
+			return fmt.Errorf("Search %q returned contact %q (%s), which matches none of its text fields", term, c.ID, c.Name)
+		}
+	}
+	if !found {
+		return fmt.Errorf("Search %q did not return contact %q (%s)", term, target.ID, target.Name)
+	}
+	return nil
+}
+
+// contactText returns the text fields of c that a plugin may search.
+func contactText(c models.Contact) []string {
+	text := []string{c.ID, c.Name, c.Email, c.CompanyName, c.TaxID, c.Currency}
+	for _, p := range c.Phones {
+		text = append(text, p.Number)
+	}
+	for _, a := range []*models.Address{c.BillingAddress, c.ShippingAddress} {
+		if a != nil {
+			text = append(text, a.String())
+		}
+	}
+	for _, v := range c.Attributes {
+		text = append(text, v)
+	}
+	return text
+}
+
+func checkContactsSearchIDs(ctx context.Context, s *Suite) error {
+	all, err := s.allContacts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(all.items) == 0 {
+		return skip("no contacts to select IDs from")
+	}
+	want := []string{all.items[0].ID}
+	if last := all.items[len(all.items)-1].ID; last != want[0] {
+		want = append(want, last)
+	}
+	p := s.baseContactParams()
+	p.SearchIDs = want
+	got, err := s.walkContacts(ctx, p)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for _, c := range got.items {
+		if !slices.Contains(want, c.ID) {
+			return fmt.Errorf("SearchIDs %v returned unexpected contact %q", want, c.ID)
+		}
+		ids = append(ids, c.ID)
+	}
+	for _, id := range want {
+		if !slices.Contains(ids, id) {
+			return fmt.Errorf("SearchIDs %v did not return contact %q", want, id)
+		}
+	}
+	return nil
+}
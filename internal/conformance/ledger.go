@@ -0,0 +1,147 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// ledgerWalk is the result of following NextCursor until exhausted.
+type ledgerWalk struct {
+	pages   []models.Ledger
+	entries []models.LedgerEntry
+}
+
+func (s *Suite) walkLedger(ctx context.Context, params models.LedgerQueryParams) (*ledgerWalk, error) {
+	w := &ledgerWalk{}
+	seenCursors := map[string]bool{}
+	for page := 0; ; page++ {
+		if page >= s.cfg.MaxPages {
+			return nil, fmt.Errorf("pagination did not finish within %d pages", s.cfg.MaxPages)
+		}
+		out, err := s.fetchLedger(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("Ledger (page %d): %w", page+1, err)
+		}
+		if out == nil {
+			return nil, fmt.Errorf("Ledger (page %d) returned nil", page+1)
+		}
+		w.pages = append(w.pages, *out)
+		w.entries = append(w.entries, out.Entries...)
+		if out.NextCursor == nil || *out.NextCursor == "" {
+			return w, nil
+		}
+		if seenCursors[*out.NextCursor] {
+			return nil, fmt.Errorf("cursor %q returned twice", *out.NextCursor)
+		}
+		seenCursors[*out.NextCursor] = true
+		params.Cursor = *out.NextCursor
+	}
+}
+
+// entryDate parses the date of e, which must be set.
+func entryDate(e models.LedgerEntry) (models.Date, error) {
+	d, err := e.ParseDate()
+	if err != nil {
+		return d, fmt.Errorf("entry %d: %w", e.ID, err)
+	}
+	if d.IsZero() {
+		return d, fmt.Errorf("entry %d has no date", e.ID)
+	}
+	return d, nil
+}
+
+func checkLedgerPagination(ctx context.Context, s *Suite) error {
+	w, err := s.allLedger(ctx)
+	if err != nil {
+		return err
+	}
+	seen := map[int64]bool{}
+	for _, e := range w.entries {
+		if seen[e.ID] {
+			return fmt.Errorf("duplicate ledger entry ID %d across pages", e.ID)
+		}
+		seen[e.ID] = true
+	}
+	return nil
+}
+
+func checkLedgerDateRange(ctx context.Context, s *Suite) error {
+	all, err := s.allLedger(ctx)
+	if err != nil {
+		return err
+	}
+	dates := make([]models.Date, 0, len(all.entries))
+	byID := map[int64]models.Date{}
+	for _, e := range all.entries {
+		d, err := entryDate(e)
+		if err != nil {
+			return err
+		}
+		dates = append(dates, d)
+		byID[e.ID] = d
+	}
+	if len(dates) < 2 {
+		return skip("need at least 2 ledger entries, got %d", len(dates))
+	}
+	slices.SortFunc(dates, models.Date.Compare)
+	start, end := dates[len(dates)/4], dates[len(dates)*3/4]
+
+	p := s.baseLedgerParams()
+	p.StartDate, p.EndDate = start.String(), end.String()
+	got, err := s.walkLedger(ctx, p)
+	if err != nil {
+		return err
+	}
+	returned := map[int64]bool{}
+	for _, e := range got.entries {
+		d, err := entryDate(e)
+		if err != nil {
+			return err
+		}
+		if d.Before(start) || d.After(end) {
+			return fmt.Errorf("entry %d dated %s is outside %s..%s", e.ID, e.Date, p.StartDate, p.EndDate)
+		}
+		returned[e.ID] = true
+	}
+	for id, d := range byID {
+		if !d.Before(start) && !d.After(end) && !returned[id] {
+			return fmt.Errorf("entry %d dated %s is inside %s..%s but was not returned", id, d, p.StartDate, p.EndDate)
+		}
+	}
+	return nil
+}
+
+func checkLedgerDocTypes(ctx context.Context, s *Suite) error {
+	all, err := s.allLedger(ctx)
+	if err != nil {
+		return err
+	}
+	if len(all.entries) == 0 {
+		return skip("no ledger entries")
+	}
+	want := all.entries[0].DocType
+	expected := 0
+	for _, e := range all.entries {
+		if e.DocType == want {
+			expected++
+		}
+	}
+	p := s.baseLedgerParams()
+	p.DocTypes = []models.DocType{want}
+	got, err := s.walkLedger(ctx, p)
+	if err != nil {
+		return err
+	}
+	for _, e := range got.entries {
+		if e.DocType != want {
+			return fmt.Errorf("DocTypes [%s] returned entry %d of type %s", string(want), e.ID, string(e.DocType))
+		}
+	}
+	if len(got.entries) != expected {
+		return fmt.Errorf("DocTypes [%s] returned %d entries, expected %d", string(want), len(got.entries), expected)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+// Package credstore keeps plugin credentials in ~/.uag/credentials,
+// encrypted with AES-256-GCM under a key derived from a passphrase with
+// scrypt.
+//
+// Each plugin profile is its own file, <dir>/<plugin>/<profile>.json, so
+// profiles can be listed and removed without the passphrase. Every file has
+// its own nonce, and the plugin and profile names are bound to the
+// ciphertext so a file cannot be swapped for another. Files written by one
+// Store share a salt, so that a long-running process derives each key once.
+package credstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"golang.org/x/crypto/scrypt"
+)
+
+// DefaultProfile is used when no profile is named.
+const DefaultProfile = "default"
+
+const (
+	fileVersion = 1
+	kdfScrypt   = "scrypt"
+	keyLen      = 32
+	saltLen     = 16
+)
+
+// scrypt cost parameters for new files; existing files keep their own.
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// deriveKey is replaced in tests to count key derivations.
+var deriveKey = scrypt.Key
+
+var (
+	// ErrNotFound is returned when a plugin has no stored profile by that name.
+	ErrNotFound = errors.New("no stored credentials")
+	// ErrWrongPassphrase is returned when a file cannot be decrypted.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted credentials file")
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Entry describes one stored profile.
+type Entry struct {
+	Plugin    string    `json:"plugin"`
+	Profile   string    `json:"profile"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// file is the on-disk envelope of one profile.
+type file struct {
+	Version    int       `json:"version"`
+	KDF        string    `json:"kdf"`
+	N          int       `json:"n"`
+	R          int       `json:"r"`
+	P          int       `json:"p"`
+	Salt       []byte    `json:"salt"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Store reads and writes encrypted profiles in Dir. Passphrase is called at
+// most once, the first time a profile is encrypted or decrypted. A Store is
+// safe for concurrent use and must not be copied.
+type Store struct {
+	Dir        string
+	Passphrase func() ([]byte, error)
+
+	mu        sync.Mutex
+	pass      []byte
+	writeSalt []byte
+	// keys caches derived keys by KDF parameters and salt.
+	keys map[string][]byte
+}
+
+// Path returns the default store location.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".uag", "credentials"), nil
+}
+
+// ValidateName rejects plugin and profile names that are not safe file names.
+func ValidateName(kind, name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid %s name %q: use letters, digits, '.', '_' and '-'", kind, name)
+	}
+	return nil
+}
+
+func (s *Store) path(plugin, profile string) (string, error) {
+	if err := ValidateName("plugin", plugin); err != nil {
+		return "", err
+	}
+	if err := ValidateName("profile", profile); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Dir, plugin, profile+".json"), nil
+}
+
+// passphrase returns the passphrase, asking for it on first use. s.mu must
+// be held.
+func (s *Store) passphrase() ([]byte, error) {
+	if s.pass != nil {
+		return s.pass, nil
+	}
+	if s.Passphrase == nil {
+		return nil, errors.New("no passphrase source configured")
+	}
+	pass, err := s.Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if len(pass) == 0 {
+		return nil, errors.New("the passphrase must not be empty")
+	}
+	s.pass = pass
+	return pass, nil
+}
+
+// aead returns the cipher for a file with the given salt and scrypt
+// parameters, deriving its key only the first time.
+func (s *Store) aead(salt []byte, n, r, p int) (cipher.AEAD, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pass, err := s.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	id := fmt.Sprintf("%d/%d/%d/%x", n, r, p, salt)
+	key, ok := s.keys[id]
+	if !ok {
+		if key, err = deriveKey(pass, salt, n, r, p, keyLen); err != nil {
+			return nil, fmt.Errorf("failed to derive the key: %w", err)
+		}
+		if s.keys == nil {
+			s.keys = map[string][]byte{}
+		}
+		s.keys[id] = key
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// salt returns the salt for files this Store writes.
+func (s *Store) salt() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writeSalt == nil {
+		salt := make([]byte, saltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		s.writeSalt = salt
+	}
+	return s.writeSalt, nil
+}
+
+// Unlock asks for the passphrase now instead of on first use, e.g. before
+// the store is shared between goroutines.
+func (s *Store) Unlock() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.passphrase()
+	return err
+}
+
+// Get decrypts the credentials stored for plugin under profile.
+func (s *Store) Get(plugin, profile string) (models.AuthCredentials, error) {
+	creds := models.AuthCredentials{}
+	if err := s.Load(plugin, profile, &creds); errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w (run 'uagplugin creds set %s %s')", err, plugin, profile)
+	} else if err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// Set encrypts creds and stores them for plugin under profile, replacing any
+// previous value.
+func (s *Store) Set(plugin, profile string, creds models.AuthCredentials) error {
+	return s.Save(plugin, profile, creds)
+}
+
+// Load decrypts the value stored for plugin under profile and unmarshals its
+// JSON into v.
+func (s *Store) Load(plugin, profile string, v any) error {
+	path, err := s.path(plugin, profile)
+	if err != nil {
+		return err
+	}
+	f, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w for %s profile %q", ErrNotFound, plugin, profile)
+	}
+	if err != nil {
+		return err
+	}
+	if f.Version != fileVersion || f.KDF != kdfScrypt {
+		return fmt.Errorf("%s: unsupported credentials file (version %d, kdf %q)", path, f.Version, f.KDF)
+	}
+	gcm, err := s.aead(f.Salt, f.N, f.R, f.P)
+	if err != nil {
+		return err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return fmt.Errorf("%s: %w", path, ErrWrongPassphrase)
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, additionalData(plugin, profile))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, ErrWrongPassphrase)
+	}
+	if err := json.Unmarshal(plain, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Save marshals v to JSON, encrypts it and stores it for plugin under
+// profile, replacing any previous value.
+func (s *Store) Save(plugin, profile string, v any) error {
+	path, err := s.path(plugin, profile)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	salt, err := s.salt()
+	if err != nil {
+		return err
+	}
+	f := file{Version: fileVersion, KDF: kdfScrypt, N: scryptN, R: scryptR, P: scryptP, Salt: salt, UpdatedAt: time.Now().UTC()}
+	gcm, err := s.aead(f.Salt, f.N, f.R, f.P)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plain, additionalData(plugin, profile))
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Remove deletes one profile, and the plugin's directory once it is empty.
+func (s *Store) Remove(plugin, profile string) error {
+	path, err := s.path(plugin, profile)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w for %s profile %q", ErrNotFound, plugin, profile)
+	} else if err != nil {
+		return err
+	}
+	// Fails harmlessly while other profiles remain.
+	_ = os.Remove(filepath.Dir(path))
+	return nil
+}
+
+// List returns the stored profiles sorted by plugin and profile, limited to
+// plugin when it is not empty. It does not need the passphrase.
+func (s *Store) List(plugin string) ([]Entry, error) {
+	plugins := []string{plugin}
+	if plugin == "" {
+		dirs, err := os.ReadDir(s.Dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		plugins = plugins[:0]
+		for _, d := range dirs {
+			if d.IsDir() {
+				plugins = append(plugins, d.Name())
+			}
+		}
+	} else if err := ValidateName("plugin", plugin); err != nil {
+		return nil, err
+	}
+
+	var out []Entry
+	for _, p := range plugins {
+		files, err := os.ReadDir(filepath.Join(s.Dir, p))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, fi := range files {
+			profile, ok := strings.CutSuffix(fi.Name(), ".json")
+			if !ok || fi.IsDir() {
+				continue
+			}
+			e := Entry{Plugin: p, Profile: profile}
+			if f, err := readFile(filepath.Join(s.Dir, p, fi.Name())); err == nil {
+				e.UpdatedAt = f.UpdatedAt
+			}
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Plugin != out[j].Plugin {
+			return out[i].Plugin < out[j].Plugin
+		}
+		return out[i].Profile < out[j].Profile
+	})
+	return out, nil
+}
+
+func readFile(path string) (*file, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &file{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+func additionalData(plugin, profile string) []byte {
+	return []byte(plugin + "/" + profile)
+}
@@ -0,0 +1,185 @@
+package credstore
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"golang.org/x/crypto/scrypt"
+)
+
+func init() {
+	// Keep key derivation cheap in tests.
+	scryptN = 1 << 10
+}
+
+func passphrase(s string) func() ([]byte, error) {
+	return func() ([]byte, error) { return []byte(s), nil }
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s := &Store{Dir: dir, Passphrase: passphrase("correct horse")}
+	creds := models.AuthCredentials{"api_key": "sk-123", "tenant": "acme"}
+
+	t.Run("should round trip credentials", func(t *testing.T) {
+		if err := s.Set("billing", DefaultProfile, creds); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got, err := (&Store{Dir: dir, Passphrase: passphrase("correct horse")}).Get("billing", DefaultProfile)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got["api_key"] != "sk-123" || got["tenant"] != "acme" {
+			t.Errorf("Expected stored credentials, got %v", got)
+		}
+	})
+
+	t.Run("should not store plaintext and restrict permissions", func(t *testing.T) {
+		path := filepath.Join(dir, "billing", DefaultProfile+".json")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bytes.Contains(data, []byte("sk-123")) {
+			t.Errorf("Expected the secret to be encrypted, got %s", data)
+		}
+		if st, _ := os.Stat(path); st.Mode().Perm() != 0600 {
+			t.Errorf("Expected mode 0600, got %v", st.Mode().Perm())
+		}
+	})
+
+	t.Run("should reject a wrong passphrase", func(t *testing.T) {
+		_, err := (&Store{Dir: dir, Passphrase: passphrase("wrong")}).Get("billing", DefaultProfile)
+		if !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Expected ErrWrongPassphrase, got %v", err)
+		}
+	})
+
+	t.Run("should reject a file moved to another profile", func(t *testing.T) {
+		src := filepath.Join(dir, "billing", DefaultProfile+".json")
+		data, _ := os.ReadFile(src)
+		if err := os.WriteFile(filepath.Join(dir, "billing", "staging.json"), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(filepath.Join(dir, "billing", "staging.json"))
+		if _, err := s.Get("billing", "staging"); !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Expected ErrWrongPassphrase, got %v", err)
+		}
+	})
+
+	t.Run("should report missing profiles", func(t *testing.T) {
+		if _, err := s.Get("billing", "prod"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+		if err := s.Remove("billing", "prod"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should reject unsafe names", func(t *testing.T) {
+		for _, name := range []string{"", "../x", "a/b", ".hidden"} {
+			if err := s.Set(name, DefaultProfile, creds); err == nil {
+				t.Errorf("Expected an error for plugin %q", name)
+			}
+		}
+	})
+
+	t.Run("should list profiles without the passphrase", func(t *testing.T) {
+		if err := s.Set("billing", "staging", creds); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Set("crm", DefaultProfile, creds); err != nil {
+			t.Fatal(err)
+		}
+		locked := &Store{Dir: dir}
+		all, err := locked.List("")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(all) != 3 || all[0].Plugin != "billing" || all[0].Profile != "default" || all[1].Profile != "staging" || all[2].Plugin != "crm" {
+			t.Errorf("Expected billing/default, billing/staging, crm/default, got %+v", all)
+		}
+		if all[0].UpdatedAt.IsZero() {
+			t.Errorf("Expected UpdatedAt to be set")
+		}
+		one, _ := locked.List("crm")
+		if len(one) != 1 || one[0].Plugin != "crm" {
+			t.Errorf("Expected crm/default, got %+v", one)
+		}
+	})
+
+	t.Run("should remove the plugin directory with its last profile", func(t *testing.T) {
+		if err := s.Remove("crm", DefaultProfile); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "crm")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected the crm directory to be removed, got %v", err)
+		}
+	})
+
+	t.Run("should ask for the passphrase once", func(t *testing.T) {
+		calls := 0
+		s := &Store{Dir: dir, Passphrase: func() ([]byte, error) { calls++; return []byte("correct horse"), nil }}
+		_, _ = s.Get("billing", DefaultProfile)
+		_, _ = s.Get("billing", "staging")
+		if calls != 1 {
+			t.Errorf("Expected 1 call, got %d", calls)
+		}
+	})
+	t.Run("should round trip any JSON value", func(t *testing.T) {
+		type token struct {
+			Access  string `json:"access"`
+			Refresh string `json:"refresh"`
+		}
+		s := &Store{Dir: t.TempDir(), Passphrase: passphrase("correct horse")}
+		if err := s.Save("crm", "oauth2", token{"at-1", "rt-1"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var got token
+		if err := s.Load("crm", "oauth2", &got); err != nil || got.Access != "at-1" || got.Refresh != "rt-1" {
+			t.Errorf("Expected the stored token, got %+v, %v", got, err)
+		}
+		if err := s.Load("crm", DefaultProfile, &got); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+	t.Run("should derive each key once when shared between goroutines", func(t *testing.T) {
+		dir := t.TempDir()
+		writer := &Store{Dir: dir, Passphrase: passphrase("correct horse")}
+		for _, profile := range []string{"a", "b"} {
+			if err := writer.Set("billing", profile, creds); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var derived atomic.Int32
+		defer func(orig func([]byte, []byte, int, int, int, int) ([]byte, error)) { deriveKey = orig }(deriveKey)
+		deriveKey = func(pass, salt []byte, n, r, p, keyLen int) ([]byte, error) {
+			derived.Add(1)
+			return scrypt.Key(pass, salt, n, r, p, keyLen)
+		}
+		var asked atomic.Int32
+		s := &Store{Dir: dir, Passphrase: func() ([]byte, error) { asked.Add(1); return []byte("correct horse"), nil }}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, profile := range []string{"a", "b"} {
+					if _, err := s.Get("billing", profile); err != nil {
+						t.Errorf("Expected no error, got %v", err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if asked.Load() != 1 || derived.Load() != 1 {
+			t.Errorf("Expected 1 passphrase prompt and 1 key derivation, got %d and %d", asked.Load(), derived.Load())
+		}
+	})
+}
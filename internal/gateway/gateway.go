@@ -0,0 +1,269 @@
+// Package gateway exposes loaded plugins as a REST API for backends that do
+// not embed Go plugins themselves.
+//
+// Responses use the JSON shapes of the models package. Query parameters map
+// onto models.ContactQueryParams and models.LedgerQueryParams by their JSON
+// field names, and OpenAPI describes every route from the same models.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/logger"
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// CredentialsHeader carries AuthCredentials for contacts and ledger requests
+// as a JSON object. A bearer token in the Authorization header is passed as
+// access_token.
+const CredentialsHeader = "X-UAG-Credentials"
+
+// DefaultTimeout bounds each plugin call when Server.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
+// maxBodyBytes limits POST bodies.
+const maxBodyBytes = 1 << 20
+
+// PluginInfo is one entry of GET /plugins.
+type PluginInfo struct {
+	ID        string           `json:"id"`
+	Transport host.Transport   `json:"transport,omitempty"`
+	Meta      *models.MetaData `json:"meta,omitempty"`
+	// Error is set when the plugin's metadata could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthStatus is the body of GET /plugins/{id}/health.
+type HealthStatus struct {
+	Status string `json:"status"`
+}
+
+// ErrorBody is the body of every error response.
+type ErrorBody struct {
+	Error string `json:"error"`
+}
+
+// Server serves Plugins, keyed by the id used in request paths.
+type Server struct {
+	Plugins map[string]*host.Plugin
+	// Timeout bounds each plugin call (default DefaultTimeout).
+	Timeout time.Duration
+	// Credentials, if set, completes the credentials of a contacts or ledger
+	// request, e.g. with stored profiles or OAuth2 tokens.
+	Credentials func(ctx context.Context, id string, p *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error)
+}
+
+// Handler returns the HTTP handler for all routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.json", s.openAPI)
+	mux.HandleFunc("GET /plugins", s.list)
+	mux.HandleFunc("GET /plugins/{id}/meta", s.withPlugin(s.meta))
+	mux.HandleFunc("GET /plugins/{id}/health", s.withPlugin(s.health))
+	mux.HandleFunc("POST /plugins/{id}/auth", s.withPlugin(s.auth))
+	mux.HandleFunc("GET /plugins/{id}/contacts", s.withPlugin(s.contacts))
+	mux.HandleFunc("GET /plugins/{id}/ledger", s.withPlugin(s.ledger))
+	return logRequests(mux)
+}
+
+// statusError carries the HTTP status for errors raised by the gateway itself.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string { return e.err.Error() }
+func (e *statusError) Unwrap() error { return e.err }
+
+func badRequest(format string, args ...any) error {
+	return &statusError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+type pluginHandler func(ctx context.Context, r *http.Request, id string, p *host.Plugin) (any, error)
+
+// withPlugin resolves {id}, bounds the call by the timeout and writes the
+// result or error.
+func (s *Server) withPlugin(h pluginHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		p, ok := s.Plugins[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, ErrorBody{Error: fmt.Sprintf("plugin %q is not loaded", id)})
+			return
+		}
+		timeout := s.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		v, err := h(ctx, r, id, p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	ids := slices.Sorted(maps.Keys(s.Plugins))
+	out := make([]PluginInfo, 0, len(ids))
+	for _, id := range ids {
+		p := s.Plugins[id]
+		info := PluginInfo{ID: id, Transport: p.Transport}
+		if meta, err := callMeta(r.Context(), p); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Meta = meta
+		}
+		out = append(out, info)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) meta(ctx context.Context, _ *http.Request, _ string, p *host.Plugin) (any, error) {
+	return callMeta(ctx, p)
+}
+
+func (s *Server) health(ctx context.Context, _ *http.Request, _ string, p *host.Plugin) (any, error) {
+	return host.Bounded(ctx, func() (HealthStatus, error) { return HealthStatus{Status: p.HealthContext(ctx)}, nil })
+}
+
+func (s *Server) auth(ctx context.Context, r *http.Request, _ string, p *host.Plugin) (any, error) {
+	var params models.AuthParams
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&params); err != nil {
+		return nil, badRequest("invalid AuthParams body: %v", err)
+	}
+	return host.Bounded(ctx, func() (*models.AuthCredentials, error) { return p.AuthenticateContext(ctx, params) })
+}
+
+func (s *Server) contacts(ctx context.Context, r *http.Request, id string, p *host.Plugin) (any, error) {
+	var params models.ContactQueryParams
+	if err := DecodeQuery(r.URL.Query(), &params); err != nil {
+		return nil, &statusError{status: http.StatusBadRequest, err: err}
+	}
+	auth, err := s.credentials(ctx, r, id, p)
+	if err != nil {
+		return nil, err
+	}
+	return host.Bounded(ctx, func() (*models.Contacts, error) { return p.ContactsContext(ctx, auth, params) })
+}
+
+func (s *Server) ledger(ctx context.Context, r *http.Request, id string, p *host.Plugin) (any, error) {
+	var params models.LedgerQueryParams
+	if err := DecodeQuery(r.URL.Query(), &params); err != nil {
+		return nil, &statusError{status: http.StatusBadRequest, err: err}
+	}
+	if err := params.Validate(); err != nil {
+		return nil, &statusError{status: http.StatusBadRequest, err: err}
+	}
+	auth, err := s.credentials(ctx, r, id, p)
+	if err != nil {
+		return nil, err
+	}
+	l, err := host.Bounded(ctx, func() (*models.Ledger, error) { return p.LedgerContext(ctx, auth, params) })
+	if err != nil {
+		return nil, err
+	}
+	if err := host.FillBalances(l, params); err != nil {
+		return nil, fmt.Errorf("balances: %w", err)
+	}
+	return l, nil
+}
+
+// credentials reads the request's credentials and completes them with the
+// Credentials hook.
+func (s *Server) credentials(ctx context.Context, r *http.Request, id string, p *host.Plugin) (models.AuthCredentials, error) {
+	auth := models.AuthCredentials{}
+	if h := r.Header.Get(CredentialsHeader); h != "" {
+		if err := json.Unmarshal([]byte(h), &auth); err != nil {
+			return nil, badRequest("invalid %s header: %v", CredentialsHeader, err)
+		}
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && auth["access_token"] == "" {
+		auth["access_token"] = strings.TrimSpace(token)
+	}
+	if s.Credentials == nil {
+		return auth, nil
+	}
+	return s.Credentials(ctx, id, p, auth)
+}
+
+func (s *Server) openAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, OpenAPI())
+}
+
+// callMeta bounds Meta, which native plugins call without a context, by ctx.
+func callMeta(ctx context.Context, p *host.Plugin) (*models.MetaData, error) {
+	meta, err := host.Bounded(ctx, func() (*models.MetaData, error) { return p.MetaContext(ctx) })
+	if err == nil && meta == nil {
+		err = errors.New("plugin returned no metadata")
+	}
+	return meta, err
+}
+
+// statusFor maps an error to the HTTP status reported for it.
+func statusFor(err error) int {
+	var se *statusError
+	switch {
+	case errors.As(err, &se):
+		return se.status
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, host.ErrMissingCredentials):
+		return http.StatusBadRequest
+	case errors.Is(err, host.ErrNoToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, host.ErrNoAuthenticator):
+		return http.StatusNotImplemented
+	}
+	// Anything else failed inside the plugin.
+	return http.StatusBadGateway
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusFor(err), ErrorBody{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		logger.Debug("Failed to write response: %v", err)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		logger.Debug("%s %s %d (%s)", r.Method, r.URL.RequestURI(), rec.status, time.Since(start))
+	})
+}
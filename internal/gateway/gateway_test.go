@@ -0,0 +1,331 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// fakePlugin records the last query and credentials it was called with.
+type fakePlugin struct {
+	contactParams models.ContactQueryParams
+	ledgerParams  models.LedgerQueryParams
+	auth          models.AuthCredentials
+	// release, if set, blocks Contacts until it is closed.
+	release chan struct{}
+}
+
+func (p *fakePlugin) Meta() *models.MetaData {
+	return &models.MetaData{ID: "fake", Name: "Fake", AuthType: models.AuthTypeAPIKey}
+}
+func (p *fakePlugin) Health() string { return "ok" }
+
+func (p *fakePlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	if p.release != nil {
+		<-p.release
+		return nil, errors.New("released")
+	}
+	if params.Search == "panic" {
+		panic("boom")
+	}
+	p.auth, p.contactParams = auth, params
+	return &models.Contacts{Items: []models.Contact{{ID: "1", Name: "Ada"}}, Count: 1, Total: 1}, nil
+}
+
+func (p *fakePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	p.auth, p.ledgerParams = auth, params
+	if params.CustomerID == "" {
+		return nil, errors.New("customer_id is required")
+	}
+	return &models.Ledger{CustomerName: "Ada", OpeningBalance: models.MustParseMoney("1.00"), Entries: []models.LedgerEntry{{ID: 1, Date: "2025-01-02", Amount: models.MustParseMoney("5.00")}}}, nil
+}
+
+type authPlugin struct{ fakePlugin }
+
+func (p *authPlugin) Auth(params models.AuthParams) (*models.AuthCredentials, error) {
+	return &models.AuthCredentials{"token": "t-" + params.APIKey}, nil
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakePlugin, *authPlugin) {
+	t.Helper()
+	fake, withAuth := &fakePlugin{}, &authPlugin{}
+	s := &Server{
+		Plugins: map[string]*host.Plugin{"fake": host.Wrap(fake), "secure": host.Wrap(withAuth)},
+		Timeout: time.Second,
+	}
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts, fake, withAuth
+}
+
+func getJSON(t *testing.T, req *http.Request, v any) int {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Expected a JSON body, got %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func get(t *testing.T, u string, v any) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, u, nil)
+	return getJSON(t, req, v)
+}
+
+func TestServer(t *testing.T) {
+	ts, fake, _ := newTestServer(t)
+
+	t.Run("should list plugins with their metadata", func(t *testing.T) {
+		var list []PluginInfo
+		if code := get(t, ts.URL+"/plugins", &list); code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", code)
+		}
+		if len(list) != 2 || list[0].ID != "fake" || list[0].Meta == nil || list[0].Meta.Name != "Fake" || list[1].ID != "secure" {
+			t.Errorf("Expected fake and secure, got %+v", list)
+		}
+	})
+
+	t.Run("should serve meta and health", func(t *testing.T) {
+		var meta models.MetaData
+		if code := get(t, ts.URL+"/plugins/fake/meta", &meta); code != http.StatusOK || meta.ID != "fake" {
+			t.Errorf("Expected fake metadata, got %d %+v", code, meta)
+		}
+		var health HealthStatus
+		if code := get(t, ts.URL+"/plugins/fake/health", &health); code != http.StatusOK || health.Status != "ok" {
+			t.Errorf("Expected ok, got %d %+v", code, health)
+		}
+	})
+
+	t.Run("should return 404 for unknown plugins", func(t *testing.T) {
+		var body ErrorBody
+		if code := get(t, ts.URL+"/plugins/nope/meta", &body); code != http.StatusNotFound || body.Error == "" {
+			t.Errorf("Expected 404 with an error, got %d %+v", code, body)
+		}
+	})
+
+	t.Run("should map query parameters onto ContactQueryParams", func(t *testing.T) {
+		var contacts models.Contacts
+		code := get(t, ts.URL+"/plugins/fake/contacts?search=ada&search_ids=1,2&search_ids=3&limit=5&page=2&sort_descending=true&extras[region]=eu", &contacts)
+		if code != http.StatusOK || len(contacts.Items) != 1 {
+			t.Fatalf("Expected one contact, got %d %+v", code, contacts)
+		}
+		p := fake.contactParams
+		if p.Search != "ada" || strings.Join(p.SearchIDs, ",") != "1,2,3" || p.Limit != 5 || p.Page != 2 || !p.SortDescending || p.Extras["region"] != "eu" {
+			t.Errorf("Expected mapped params, got %+v", p)
+		}
+	})
+
+	t.Run("should map query parameters onto LedgerQueryParams", func(t *testing.T) {
+		var ledger models.Ledger
+		code := get(t, ts.URL+"/plugins/fake/ledger?customer_id=c1&start_date=2025-01-01&doc_types=invoice,payment", &ledger)
+		if code != http.StatusOK || ledger.CustomerName != "Ada" {
+			t.Fatalf("Expected the ledger, got %d %+v", code, ledger)
+		}
+		if ledger.Entries[0].Balance != nil || ledger.ClosingBalance != nil {
+			t.Errorf("Expected no balances for a doc_types filter, got %+v", ledger)
+		}
+		p := fake.ledgerParams
+		if p.CustomerID != "c1" || p.StartDate != "2025-01-01" || len(p.DocTypes) != 2 || p.DocTypes[1] != models.DocTypePayment {
+			t.Errorf("Expected mapped params, got %+v", p)
+		}
+	})
+
+	t.Run("should fill in balances of a whole ledger", func(t *testing.T) {
+		var ledger models.Ledger
+		if code := get(t, ts.URL+"/plugins/fake/ledger?customer_id=c1", &ledger); code != http.StatusOK {
+			t.Fatalf("Expected the ledger, got %d %+v", code, ledger)
+		}
+		if b := ledger.Entries[0].Balance; b == nil || b.String() != "6.00" || ledger.ClosingBalance == nil || ledger.ClosingBalance.String() != "6.00" {
+			t.Errorf("Expected balances filled in, got %+v", ledger)
+		}
+	})
+
+	t.Run("should reject unknown and malformed query parameters", func(t *testing.T) {
+		for _, q := range []string{"serch=x", "limit=ten", "extras=x", "search[x]=y"} {
+			var body ErrorBody
+			if code := get(t, ts.URL+"/plugins/fake/contacts?"+q, &body); code != http.StatusBadRequest {
+				t.Errorf("Expected 400 for %q, got %d", q, code)
+			}
+		}
+	})
+
+	t.Run("should reject invalid ledger date ranges", func(t *testing.T) {
+		for _, q := range []string{"start_date=01/02/2025", "start_date=2025-02-01&end_date=2025-01-01"} {
+			var body ErrorBody
+			if code := get(t, ts.URL+"/plugins/fake/ledger?customer_id=c1&"+q, &body); code != http.StatusBadRequest {
+				t.Errorf("Expected 400 for %q, got %d", q, code)
+			}
+		}
+	})
+
+	t.Run("should report plugin errors as 502", func(t *testing.T) {
+		var body ErrorBody
+		if code := get(t, ts.URL+"/plugins/fake/ledger", &body); code != http.StatusBadGateway || body.Error != "customer_id is required" {
+			t.Errorf("Expected 502 with the plugin error, got %d %+v", code, body)
+		}
+	})
+
+	t.Run("should recover plugin panics", func(t *testing.T) {
+		var body ErrorBody
+		if code := get(t, ts.URL+"/plugins/fake/contacts?search=panic", &body); code != http.StatusBadGateway || !strings.Contains(body.Error, "boom") {
+			t.Errorf("Expected 502 with the panic, got %d %+v", code, body)
+		}
+	})
+
+	t.Run("should pass credentials from headers", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/plugins/fake/contacts", nil)
+		req.Header.Set(CredentialsHeader, `{"api_key":"k"}`)
+		req.Header.Set("Authorization", "Bearer tok")
+		if code := getJSON(t, req, nil); code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", code)
+		}
+		if fake.auth["api_key"] != "k" || fake.auth["access_token"] != "tok" {
+			t.Errorf("Expected api_key and access_token, got %v", fake.auth)
+		}
+	})
+
+	t.Run("should call Auth with the posted AuthParams", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/plugins/secure/auth", strings.NewReader(`{"api_key":"k"}`))
+		var creds models.AuthCredentials
+		if code := getJSON(t, req, &creds); code != http.StatusOK || creds["token"] != "t-k" {
+			t.Errorf("Expected token t-k, got %d %v", code, creds)
+		}
+		req, _ = http.NewRequest(http.MethodPost, ts.URL+"/plugins/fake/auth", strings.NewReader(`{}`))
+		if code := getJSON(t, req, nil); code != http.StatusNotImplemented {
+			t.Errorf("Expected 501 for a plugin without Auth, got %d", code)
+		}
+		req, _ = http.NewRequest(http.MethodGet, ts.URL+"/plugins/secure/auth", nil)
+		if code := getJSON(t, req, nil); code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected 405 for GET, got %d", code)
+		}
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	slow := &fakePlugin{release: make(chan struct{})}
+	defer close(slow.release)
+	s := &Server{Plugins: map[string]*host.Plugin{"slow": host.Wrap(slow)}, Timeout: 50 * time.Millisecond}
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	t.Run("should time out slow calls", func(t *testing.T) {
+		var body ErrorBody
+		if code := get(t, ts.URL+"/plugins/slow/contacts", &body); code != http.StatusGatewayTimeout {
+			t.Errorf("Expected 504, got %d %+v", code, body)
+		}
+	})
+}
+
+func TestCredentialsHook(t *testing.T) {
+	fake := &fakePlugin{}
+	s := &Server{
+		Plugins: map[string]*host.Plugin{"fake": host.Wrap(fake)},
+		Credentials: func(_ context.Context, id string, _ *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error) {
+			if auth["api_key"] == "" {
+				return nil, host.ErrNoToken
+			}
+			auth["profile"] = id
+			return auth, nil
+		},
+	}
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	t.Run("should complete request credentials", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/plugins/fake/contacts", nil)
+		req.Header.Set(CredentialsHeader, `{"api_key":"k"}`)
+		if code := getJSON(t, req, nil); code != http.StatusOK || fake.auth["profile"] != "fake" {
+			t.Errorf("Expected the hook's credentials, got %d %v", code, fake.auth)
+		}
+	})
+
+	t.Run("should report missing tokens as 401", func(t *testing.T) {
+		if code := get(t, ts.URL+"/plugins/fake/contacts", nil); code != http.StatusUnauthorized {
+			t.Errorf("Expected 401, got %d", code)
+		}
+	})
+}
+
+func TestDecodeQuery(t *testing.T) {
+	t.Run("should leave absent parameters untouched", func(t *testing.T) {
+		p := models.ContactQueryParams{Search: "keep"}
+		if err := DecodeQuery(url.Values{"limit": {"3"}}, &p); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if p.Search != "keep" || p.Limit != 3 {
+			t.Errorf("Expected search kept and limit 3, got %+v", p)
+		}
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc := OpenAPI()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Expected a JSON document, got %v", err)
+	}
+	var parsed struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should describe every route", func(t *testing.T) {
+		for path, method := range map[string]string{
+			"/plugins": "get", "/plugins/{id}/meta": "get", "/plugins/{id}/health": "get",
+			"/plugins/{id}/auth": "post", "/plugins/{id}/contacts": "get", "/plugins/{id}/ledger": "get",
+		} {
+			if _, ok := parsed.Paths[path][method]; !ok {
+				t.Errorf("Expected %s %s to be documented", method, path)
+			}
+		}
+	})
+
+	t.Run("should generate schemas from the models", func(t *testing.T) {
+		entry, ok := parsed.Components.Schemas["LedgerEntry"]
+		if !ok {
+			t.Fatalf("Expected a LedgerEntry schema")
+		}
+		if entry.Properties["doc_type"]["enum"] == nil {
+			t.Errorf("Expected doc_type to list its values, got %v", entry.Properties["doc_type"])
+		}
+		if _, ok := parsed.Components.Schemas["Contact"].Properties["email"]; !ok {
+			t.Errorf("Expected Contact.email, got %v", parsed.Components.Schemas["Contact"])
+		}
+	})
+
+	t.Run("should list query parameters by JSON name", func(t *testing.T) {
+		params := doc["paths"].(map[string]any)["/plugins/{id}/ledger"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)
+		names := map[string]bool{}
+		for _, p := range params {
+			names[p.(map[string]any)["name"].(string)] = true
+		}
+		for _, n := range []string{"customer_id", "start_date", "end_date", "doc_types", "cursor", "limit", "page", "extras"} {
+			if !names[n] {
+				t.Errorf("Expected query parameter %s, got %v", n, names)
+			}
+		}
+	})
+}
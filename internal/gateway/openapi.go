@@ -0,0 +1,195 @@
+package gateway
+
+import (
+	"encoding"
+	"reflect"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/internal/version"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+// enums lists the allowed values of the models' string enums.
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[models.DocType]():  enumValues(models.DocTypes),
+	reflect.TypeFor[models.AuthType](): enumValues(models.AuthTypes),
+}
+
+// formats names the string format of types marshalled as text.
+var formats = map[reflect.Type]string{
+	reflect.TypeFor[time.Time](): "date-time",
+}
+
+var textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+
+func enumValues[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// OpenAPI returns the OpenAPI 3.0 document describing Handler's routes.
+// Schemas are generated from the models package, so contract changes show
+// up without edits here.
+func OpenAPI() map[string]any {
+	g := &schemaGen{schemas: map[string]any{}}
+	idParam := map[string]any{"name": "id", "in": "path", "required": true, "description": "Plugin id as listed by GET /plugins", "schema": map[string]any{"type": "string"}}
+	credentials := []any{map[string]any{"bearer": []any{}}, map[string]any{"credentials": []any{}}, map[string]any{}}
+
+	op := func(summary string, result reflect.Type, extra map[string]any) map[string]any {
+		o := map[string]any{
+			"summary": summary,
+			"responses": map[string]any{
+				"200":     response("OK", g.schema(result)),
+				"default": response("Error", g.schema(reflect.TypeFor[ErrorBody]())),
+			},
+		}
+		for k, v := range extra {
+			o[k] = v
+		}
+		return o
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "UAG plugin gateway",
+			"version":     version.Version,
+			"description": "Installed UAG plugins over HTTP (plugin contract " + typing.ContractVersion + ").",
+		},
+		"paths": map[string]any{
+			"/plugins": map[string]any{
+				"get": op("List loaded plugins", reflect.TypeFor[[]PluginInfo](), nil),
+			},
+			"/plugins/{id}/meta": map[string]any{
+				"parameters": []any{idParam},
+				"get":        op("Plugin metadata", reflect.TypeFor[models.MetaData](), nil),
+			},
+			"/plugins/{id}/health": map[string]any{
+				"parameters": []any{idParam},
+				"get":        op("Plugin health", reflect.TypeFor[HealthStatus](), nil),
+			},
+			"/plugins/{id}/auth": map[string]any{
+				"parameters": []any{idParam},
+				"post": op("Exchange AuthParams for AuthCredentials", reflect.TypeFor[models.AuthCredentials](), map[string]any{
+					"requestBody": map[string]any{
+						"required": true,
+						"content":  map[string]any{"application/json": map[string]any{"schema": g.schema(reflect.TypeFor[models.AuthParams]())}},
+					},
+				}),
+			},
+			"/plugins/{id}/contacts": map[string]any{
+				"parameters": []any{idParam},
+				"get": op("Query contacts", reflect.TypeFor[models.Contacts](), map[string]any{
+					"parameters": g.queryParams(reflect.TypeFor[models.ContactQueryParams]()),
+					"security":   credentials,
+				}),
+			},
+			"/plugins/{id}/ledger": map[string]any{
+				"parameters": []any{idParam},
+				"get": op("Query ledger entries", reflect.TypeFor[models.Ledger](), map[string]any{
+					"parameters": g.queryParams(reflect.TypeFor[models.LedgerQueryParams]()),
+					"security":   credentials,
+				}),
+			},
+		},
+		"components": map[string]any{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]any{
+				"bearer":      map[string]any{"type": "http", "scheme": "bearer", "description": "Passed to the plugin as the access_token credential"},
+				"credentials": map[string]any{"type": "apiKey", "in": "header", "name": CredentialsHeader, "description": "AuthCredentials as a JSON object"},
+			},
+		},
+	}
+}
+
+func response(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+	}
+}
+
+// schemaGen builds JSON schemas, collecting named structs as components.
+type schemaGen struct {
+	schemas map[string]any
+}
+
+func (g *schemaGen) schema(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if vals, ok := enums[t]; ok {
+		return map[string]any{"type": "string", "enum": vals}
+	}
+	if t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler) {
+		s := map[string]any{"type": "string"}
+		if f, ok := formats[t]; ok {
+			s["format"] = f
+		}
+		return s
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		if _, ok := g.schemas[t.Name()]; !ok {
+			g.schemas[t.Name()] = map[string]any{} // placeholder for recursive types
+			g.schemas[t.Name()] = g.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+func (g *schemaGen) object(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+	for _, f := range jsonFields(t) {
+		props[f.name] = g.schema(f.typ)
+		if !f.omitEmpty && f.typ.Kind() != reflect.Pointer {
+			required = append(required, f.name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// queryParams describes the query parameters DecodeQuery accepts for t.
+func (g *schemaGen) queryParams(t reflect.Type) []any {
+	var out []any
+	for _, f := range jsonFields(t) {
+		p := map[string]any{"name": f.name, "in": "query", "schema": g.schema(f.typ)}
+		switch f.typ.Kind() {
+		case reflect.Map:
+			p["style"], p["explode"] = "deepObject", true
+			p["description"] = "Repeatable as " + f.name + "[key]=value"
+		case reflect.Slice:
+			p["style"], p["explode"] = "form", false
+			p["description"] = "Comma-separated; may also be repeated"
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package gateway
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonField is a struct field by its JSON name. Embedded structs
+// (models.CommonParams) contribute their fields directly.
+type jsonField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields lists the fields of struct type t as encoding/json sees them.
+func jsonFields(t reflect.Type) []jsonField {
+	var out []jsonField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && tag == "" {
+			for _, sub := range jsonFields(f.Type) {
+				sub.index = append([]int{i}, sub.index...)
+				out = append(out, sub)
+			}
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out = append(out, jsonField{name: name, index: []int{i}, typ: f.Type, omitEmpty: strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")})
+	}
+	return out
+}
+
+// DecodeQuery sets the fields of the struct pointed to by dst from q, using
+// the fields' JSON names. Slices accept repeated or comma-separated values
+// and maps use name[key]=value. Unknown parameters are rejected.
+func DecodeQuery(q url.Values, dst any) error {
+	v := reflect.ValueOf(dst).Elem()
+	fields := map[string]jsonField{}
+	for _, f := range jsonFields(v.Type()) {
+		fields[f.name] = f
+	}
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name, mapKey, isMap := strings.Cut(key, "[")
+		if isMap {
+			var ok bool
+			if mapKey, ok = strings.CutSuffix(mapKey, "]"); !ok || mapKey == "" {
+				return fmt.Errorf("invalid query parameter %q", key)
+			}
+		}
+		f, ok := fields[name]
+		if !ok || isMap != (f.typ.Kind() == reflect.Map) {
+			return fmt.Errorf("unknown query parameter %q", key)
+		}
+		fv := v.FieldByIndex(f.index)
+		vals := q[key]
+		if err := setQueryValue(fv, mapKey, vals); err != nil {
+			return fmt.Errorf("invalid query parameter %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setQueryValue(fv reflect.Value, mapKey string, vals []string) error {
+	last := vals[len(vals)-1]
+	switch fv.Kind() {
+	case reflect.Map:
+		if fv.IsNil() {
+			fv.Set(reflect.MakeMap(fv.Type()))
+		}
+		elem := reflect.New(fv.Type().Elem()).Elem()
+		if err := setScalar(elem, last); err != nil {
+			return err
+		}
+		fv.SetMapIndex(reflect.ValueOf(mapKey).Convert(fv.Type().Key()), elem)
+		return nil
+	case reflect.Slice:
+		var parts []string
+		for _, s := range vals {
+			for _, p := range strings.Split(s, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					parts = append(parts, p)
+				}
+			}
+		}
+		out := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setScalar(out.Index(i), p); err != nil {
+				return err
+			}
+		}
+		fv.Set(out)
+		return nil
+	}
+	return setScalar(fv, last)
+}
+
+func setScalar(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		v.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// Package gitsource clones and fetches plugin repositories from any git host.
+//
+// Every operation is attempted anonymously first; only when the remote asks
+// for authentication are credentials tried, in order: an explicit token,
+// a ~/.netrc entry for the host (HTTP) or the SSH agent (SSH).
+package gitsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/nikhiljohn10/uagplugin/logger"
+)
+
+// Credentials are the optional secrets offered when a remote requires auth.
+type Credentials struct {
+	// Token is sent as an HTTP basic auth password. When empty, GITHUB_TOKEN
+	// is used for github.com remotes only, so it never leaks to other hosts.
+	Token string
+}
+
+// Normalize turns user input into a URL go-git can clone:
+//
+//	github.com/org/repo          -> https://github.com/org/repo
+//	https://, http://, ssh://, git://, file:// URLs are kept
+//	git@host:org/repo.git        -> kept (scp-like SSH)
+//	/srv/git/repo.git, ./fixture -> absolute local path
+func Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("repository URL cannot be empty")
+	}
+	if isLocalPath(raw) {
+		p, err := expandPath(raw)
+		if err != nil {
+			return "", err
+		}
+		if st, err := os.Stat(p); err != nil || !st.IsDir() {
+			return "", fmt.Errorf("local repository %s not found", p)
+		}
+		return p, nil
+	}
+	if !strings.Contains(raw, "://") && !isSCPLike(raw) {
+		raw = "https://" + raw
+	}
+	ep, err := transport.NewEndpoint(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL %q: %w", raw, err)
+	}
+	if ep.Protocol != "file" && ep.Host == "" {
+		return "", fmt.Errorf("invalid repository URL %q: missing host", raw)
+	}
+	return raw, nil
+}
+
+// SplitRef splits install input "repo@ref" into the repository and the ref
+// to pass to Resolve, which is empty when none is given. Only an "@" in the
+// repository path starts the ref, so the user in git@host:org/repo or
+// ssh://git@host/repo is kept, and refs may contain "/" or ":"
+// (github.com/org/repo@feature/login).
+func SplitRef(arg string) (string, string) {
+	path := 0
+	switch {
+	case strings.Contains(arg, "://"):
+		// The authority, which may hold user@, ends at the first "/".
+		path = strings.Index(arg, "://") + len("://")
+		if i := strings.Index(arg[path:], "/"); i >= 0 {
+			path += i
+		} else {
+			path = len(arg)
+		}
+	case isSCPLike(arg):
+		path = strings.Index(arg, ":") + 1
+	}
+	i := strings.Index(arg[path:], "@")
+	if i < 0 {
+		return arg, ""
+	}
+	return arg[:path+i], arg[path+i+1:]
+}
+
+// isLocalPath reports whether raw names a directory rather than a URL.
+func isLocalPath(raw string) bool {
+	if strings.Contains(raw, "://") || isSCPLike(raw) {
+		return false
+	}
+	if filepath.IsAbs(raw) || strings.HasPrefix(raw, ".") || strings.HasPrefix(raw, "~") {
+		return true
+	}
+	st, err := os.Stat(raw)
+	return err == nil && st.IsDir()
+}
+
+// isSCPLike matches "user@host:path" without a scheme.
+func isSCPLike(raw string) bool {
+	at := strings.Index(raw, "@")
+	colon := strings.Index(raw, ":")
+	slash := strings.Index(raw, "/")
+	return at > 0 && colon > at && (slash < 0 || colon < slash)
+}
+
+func expandPath(p string) (string, error) {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		p = filepath.Join(home, strings.TrimPrefix(p, "~"))
+	}
+	return filepath.Abs(p)
+}
+
+// RepoName returns the last path element of a repository URL without ".git".
+func RepoName(src string) string {
+	path := src
+	if ep, err := transport.NewEndpoint(src); err == nil {
+		path = ep.Path
+	}
+	name := filepath.Base(strings.TrimRight(filepath.ToSlash(path), "/"))
+	return strings.TrimSuffix(name, ".git")
+}
+
+// Host returns the host of a repository URL ("" for local paths).
+func Host(src string) string {
+	ep, err := transport.NewEndpoint(src)
+	if err != nil {
+		return ""
+	}
+	return ep.Host
+}
+
+// Clone clones src into dir, removing dir first if it exists.
+func Clone(ctx context.Context, dir, src string, creds Credentials) (*git.Repository, error) {
+	var repo *git.Repository
+	err := withAuth(src, creds, func(auth transport.AuthMethod) error {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove plugin directory: %w", err)
+		}
+		var err error
+		repo, err = git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{URL: src, Auth: auth})
+		return err
+	})
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return repo, nil
+}
+
+// FetchTags updates branches and tags of repo's origin remote.
+func FetchTags(ctx context.Context, repo *git.Repository, creds Credentials) error {
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return err
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return errors.New("origin remote has no URL")
+	}
+	return withAuth(urls[0], creds, func(auth transport.AuthMethod) error {
+		err := repo.FetchContext(ctx, &git.FetchOptions{RemoteName: "origin", Tags: git.AllTags, Force: true, Auth: auth})
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return nil
+		}
+		return err
+	})
+}
+
+// OriginURL returns the URL of repo's origin remote.
+func OriginURL(repo *git.Repository) string {
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return ""
+	}
+	return remote.Config().URLs[0]
+}
+
+type authMethod struct {
+	name string
+	auth transport.AuthMethod
+}
+
+// withAuth runs op anonymously, then with each available credential while
+// the remote keeps rejecting the request for lack of authentication.
+func withAuth(src string, creds Credentials, op func(transport.AuthMethod) error) error {
+	err := op(nil)
+	if err == nil || !isAuthError(err) {
+		return err
+	}
+	methods := authMethods(src, creds)
+	if len(methods) == 0 {
+		return fmt.Errorf("%w (pass --token, add a ~/.netrc entry or load a key into ssh-agent)", err)
+	}
+	for _, m := range methods {
+		logger.Debug("Anonymous access to %s failed (%v), trying %s", src, err, m.name)
+		if err = op(m.auth); err == nil || !isAuthError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("%w (tried %s)", err, methodNames(methods))
+}
+
+func authMethods(src string, creds Credentials) []authMethod {
+	ep, err := transport.NewEndpoint(src)
+	if err != nil {
+		return nil
+	}
+	var methods []authMethod
+	switch ep.Protocol {
+	case "http", "https":
+		token := creds.Token
+		if token == "" && strings.EqualFold(ep.Host, "github.com") {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		if token != "" {
+			methods = append(methods, authMethod{"token", &http.BasicAuth{Username: "access_token", Password: token}})
+		}
+		if login, password, ok := netrcLookup(ep.Host); ok {
+			methods = append(methods, authMethod{"netrc", &http.BasicAuth{Username: login, Password: password}})
+		}
+	case "ssh":
+		user := ep.User
+		if user == "" {
+			user = "git"
+		}
+		if auth, err := ssh.NewSSHAgentAuth(user); err == nil {
+			methods = append(methods, authMethod{"ssh-agent", auth})
+		} else {
+			logger.Debug("SSH agent unavailable: %v", err)
+		}
+	}
+	return methods
+}
+
+func methodNames(methods []authMethod) string {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = m.name
+	}
+	return strings.Join(names, ", ")
+}
+
+func isAuthError(err error) bool {
+	return errors.Is(err, transport.ErrAuthenticationRequired) ||
+		errors.Is(err, transport.ErrAuthorizationFailed) ||
+		errors.Is(err, transport.ErrRepositoryNotFound) ||
+		strings.Contains(err.Error(), "unable to authenticate")
+}
@@ -0,0 +1,319 @@
+package gitsource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+func TestNormalize(t *testing.T) {
+	local := t.TempDir()
+	cases := map[string]string{
+		"github.com/org/repo":               "https://github.com/org/repo",
+		"https://gitlab.example.com/g/p":    "https://gitlab.example.com/g/p",
+		"ssh://git@gitea.local:2222/o/r":    "ssh://git@gitea.local:2222/o/r",
+		"git@github.com:org/repo.git":       "git@github.com:org/repo.git",
+		"file:///srv/git/plugin.git":        "file:///srv/git/plugin.git",
+		local:                               local,
+		"  github.com/org/repo  ":           "https://github.com/org/repo",
+		"http://mirror.internal/plugin.git": "http://mirror.internal/plugin.git",
+	}
+	for in, want := range cases {
+		t.Run("should normalize "+in, func(t *testing.T) {
+			got, err := Normalize(in)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		})
+	}
+
+	t.Run("should reject empty input and missing local paths", func(t *testing.T) {
+		for _, in := range []string{"", "./does-not-exist", filepath.Join(local, "missing")} {
+			if _, err := Normalize(in); err == nil {
+				t.Errorf("Expected error for %q", in)
+			}
+		}
+	})
+}
+
+func TestSplitRef(t *testing.T) {
+	cases := []struct{ in, src, ref string }{
+		{"github.com/org/repo", "github.com/org/repo", ""},
+		{"github.com/org/repo@v1.2.3", "github.com/org/repo", "v1.2.3"},
+		{"github.com/org/repo@^1.2", "github.com/org/repo", "^1.2"},
+		{"github.com/org/repo@feature/x", "github.com/org/repo", "feature/x"},
+		{"github.com/org/repo@", "github.com/org/repo", ""},
+		{"git@github.com:org/repo.git", "git@github.com:org/repo.git", ""},
+		{"git@github.com:org/repo.git@feature/x", "git@github.com:org/repo.git", "feature/x"},
+		{"ssh://git@gitea.local:2222/o/r", "ssh://git@gitea.local:2222/o/r", ""},
+		{"ssh://git@gitea.local:2222/o/r@main", "ssh://git@gitea.local:2222/o/r", "main"},
+		{"https://user@gitlab.example.com/g/p@release/2.x", "https://user@gitlab.example.com/g/p", "release/2.x"},
+		{"file:///srv/git/plugin.git@3f2a9c1", "file:///srv/git/plugin.git", "3f2a9c1"},
+		{"./fixture@feature/x", "./fixture", "feature/x"},
+	}
+	for _, c := range cases {
+		t.Run("should split "+c.in, func(t *testing.T) {
+			src, ref := SplitRef(c.in)
+			if src != c.src || ref != c.ref {
+				t.Errorf("Expected %q, %q, got %q, %q", c.src, c.ref, src, ref)
+			}
+		})
+	}
+}
+
+func TestRepoName(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/org/uag-api":   "uag-api",
+		"git@github.com:org/uag-api.git":   "uag-api",
+		"ssh://git@host:22/group/sub/repo": "repo",
+		"/srv/git/fixture.git":             "fixture",
+		"https://host/org/repo/":           "repo",
+	}
+	for in, want := range cases {
+		if got := RepoName(in); got != want {
+			t.Errorf("RepoName(%q): expected %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	data := `
+machine git.internal login alice password s3cret
+machine other.host
+  login bob
+  password hunter2
+default login anon password guest
+`
+	t.Run("should match a machine entry", func(t *testing.T) {
+		login, password, ok := parseNetrc(data, "other.host")
+		if !ok || login != "bob" || password != "hunter2" {
+			t.Errorf("Expected bob/hunter2, got %s/%s (%v)", login, password, ok)
+		}
+	})
+	t.Run("should fall back to default", func(t *testing.T) {
+		login, _, ok := parseNetrc(data, "unknown.host")
+		if !ok || login != "anon" {
+			t.Errorf("Expected default entry, got %s (%v)", login, ok)
+		}
+	})
+	t.Run("should report no entry", func(t *testing.T) {
+		if _, _, ok := parseNetrc("machine a login x password y", "b"); ok {
+			t.Errorf("Expected no credentials")
+		}
+	})
+}
+
+func TestWithAuth(t *testing.T) {
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "none"))
+
+	t.Run("should not use credentials when anonymous access works", func(t *testing.T) {
+		calls := 0
+		err := withAuth("https://git.example.com/o/r", Credentials{Token: "tok"}, func(auth transport.AuthMethod) error {
+			calls++
+			if auth != nil {
+				t.Errorf("Expected anonymous attempt, got %v", auth)
+			}
+			return nil
+		})
+		if err != nil || calls != 1 {
+			t.Errorf("Expected one anonymous call, got %d calls, err %v", calls, err)
+		}
+	})
+
+	t.Run("should fall back to the token on auth errors", func(t *testing.T) {
+		var used []transport.AuthMethod
+		err := withAuth("https://git.example.com/o/r", Credentials{Token: "tok"}, func(auth transport.AuthMethod) error {
+			used = append(used, auth)
+			if auth == nil {
+				return transport.ErrAuthenticationRequired
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(used) != 2 {
+			t.Fatalf("Expected 2 attempts, got %d", len(used))
+		}
+		if ba, ok := used[1].(*http.BasicAuth); !ok || ba.Password != "tok" {
+			t.Errorf("Expected token basic auth, got %v", used[1])
+		}
+	})
+
+	t.Run("should not send GITHUB_TOKEN to other hosts", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKEN", "gh")
+		if m := authMethods("https://gitlab.com/o/r", Credentials{}); len(m) != 0 {
+			t.Errorf("Expected no credentials for gitlab.com, got %s", methodNames(m))
+		}
+		if m := authMethods("https://github.com/o/r", Credentials{}); len(m) != 1 {
+			t.Errorf("Expected GITHUB_TOKEN for github.com, got %s", methodNames(m))
+		}
+	})
+
+	t.Run("should not retry non-auth errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		calls := 0
+		err := withAuth("https://git.example.com/o/r", Credentials{Token: "tok"}, func(transport.AuthMethod) error {
+			calls++
+			return boom
+		})
+		if !errors.Is(err, boom) || calls != 1 {
+			t.Errorf("Expected a single failing call, got %d calls, err %v", calls, err)
+		}
+	})
+}
+
+// fixtureRepo creates a work tree repo at root/work and returns a commit
+// helper that writes plugin.go with content and commits it.
+func fixtureRepo(t *testing.T, root string) (*git.Repository, string, func(content string) plumbing.Hash) {
+	t.Helper()
+	work := filepath.Join(root, "work")
+	repo, err := git.PlainInit(work, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, _ := repo.Worktree()
+	commit := func(content string) plumbing.Hash {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(work, "plugin.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add("plugin.go"); err != nil {
+			t.Fatal(err)
+		}
+		sig := &object.Signature{Name: "t", Email: "t@example.com", When: time.Now()}
+		hash, err := wt.Commit(content, &git.CommitOptions{Author: sig})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	return repo, work, commit
+}
+
+func TestCloneLocalBareRepo(t *testing.T) {
+	root := t.TempDir()
+	repo, work, commit := fixtureRepo(t, root)
+	hash := commit("package main\n")
+	if _, err := repo.CreateTag("v1.0.0", hash, nil); err != nil {
+		t.Fatal(err)
+	}
+	bare := filepath.Join(root, "plugin.git")
+	if _, err := git.PlainClone(bare, true, &git.CloneOptions{URL: work}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []string{bare, "file://" + bare} {
+		t.Run("should clone "+src, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "clone")
+			cloned, err := Clone(context.Background(), dst, src, Credentials{})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dst, "plugin.go")); err != nil {
+				t.Errorf("Expected plugin.go in clone, got %v", err)
+			}
+			if err := FetchTags(context.Background(), cloned, Credentials{}); err != nil {
+				t.Errorf("Expected fetch to succeed, got %v", err)
+			}
+			if _, err := cloned.Tag("v1.0.0"); err != nil {
+				t.Errorf("Expected tag v1.0.0, got %v", err)
+			}
+			if OriginURL(cloned) != src {
+				t.Errorf("Expected origin %s, got %s", src, OriginURL(cloned))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	repo, work, commit := fixtureRepo(t, root)
+	v100 := commit("// 1.0.0")
+	v120 := commit("// 1.2.0")
+	rc := commit("// 1.3.0-rc.1")
+	for name, h := range map[string]plumbing.Hash{"v1.0.0": v100, "v1.2.0": v120} {
+		if _, err := repo.CreateTag(name, h, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sig := &object.Signature{Name: "t", Email: "t@example.com", When: time.Now()}
+	if _, err := repo.CreateTag("v1.3.0-rc.1", rc, &git.CreateTagOptions{Tagger: sig, Message: "rc"}); err != nil {
+		t.Fatal(err)
+	}
+	wt, _ := repo.Worktree()
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	feature := commit("// feature")
+
+	clone, err := git.PlainClone(filepath.Join(root, "clone"), false, &git.CloneOptions{URL: work})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		ref    string
+		pre    bool
+		kind   RefKind
+		name   string
+		commit plumbing.Hash
+	}{
+		{"latest", false, RefTag, "v1.2.0", v120},
+		{"", true, RefTag, "v1.3.0-rc.1", rc},
+		{"^1.0", false, RefTag, "v1.2.0", v120},
+		{">=1.0 <1.2", false, RefTag, "v1.0.0", v100},
+		{"1.0.0", false, RefTag, "v1.0.0", v100},
+		{"1.3.0-rc.1", false, RefTag, "v1.3.0-rc.1", rc},
+		{"feature", false, RefBranch, "feature", feature},
+		{v100.String()[:8], false, RefCommit, "", v100},
+		{v120.String(), false, RefCommit, "", v120},
+	}
+	for _, tc := range cases {
+		t.Run("should resolve "+tc.ref, func(t *testing.T) {
+			res, err := Resolve(clone, tc.ref, tc.pre)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if res.Kind != tc.kind || res.Name != tc.name || res.Commit != tc.commit {
+				t.Errorf("Expected %s %q at %s, got %s %q at %s", tc.kind, tc.name, tc.commit, res.Kind, res.Name, res.Commit)
+			}
+		})
+	}
+
+	t.Run("should reject unknown refs and unsatisfiable ranges", func(t *testing.T) {
+		for _, ref := range []string{"^5", "no-such-branch", "abcdef0"} {
+			if _, err := Resolve(clone, ref, false); err == nil {
+				t.Errorf("Expected error for %q", ref)
+			}
+		}
+	})
+
+	t.Run("should fall back to the default branch without release tags", func(t *testing.T) {
+		_, work2, commit2 := fixtureRepo(t, t.TempDir())
+		tip := commit2("// only")
+		untagged, err := git.PlainClone(filepath.Join(t.TempDir(), "c"), false, &git.CloneOptions{URL: work2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := Resolve(untagged, "latest", false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if res.Kind != RefDefault || res.Commit != tip {
+			t.Errorf("Expected default branch at %s, got %s", tip, res)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package gitsource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// netrcPath returns $NETRC or ~/.netrc.
+func netrcPath() string {
+	if p := os.Getenv("NETRC"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// netrcLookup returns the login and password for host from the netrc file,
+// falling back to its "default" entry.
+func netrcLookup(host string) (login, password string, ok bool) {
+	path := netrcPath()
+	if path == "" {
+		return "", "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", false
+	}
+	return parseNetrc(string(data), host)
+}
+
+func parseNetrc(data, host string) (login, password string, ok bool) {
+	type entry struct{ login, password string }
+	var (
+		match, def *entry
+		cur        *entry
+	)
+	fields := strings.Fields(data)
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			cur = nil
+			if i+1 < len(fields) {
+				i++
+				if match == nil && strings.EqualFold(fields[i], host) {
+					match = &entry{}
+					cur = match
+				}
+			}
+		case "default":
+			def = &entry{}
+			cur = def
+		case "login", "password", "account":
+			if i+1 >= len(fields) {
+				break
+			}
+			i++
+			if cur == nil {
+				continue
+			}
+			if fields[i-1] == "login" {
+				cur.login = fields[i]
+			} else if fields[i-1] == "password" {
+				cur.password = fields[i]
+			}
+		case "macdef":
+			// Macro definitions are not supported; ignore the rest of the file.
+			i = len(fields)
+		}
+	}
+	for _, e := range []*entry{match, def} {
+		if e != nil && e.password != "" {
+			return e.login, e.password, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,194 @@
+package gitsource
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nikhiljohn10/uagplugin/internal/semver"
+)
+
+// RefKind tells how a requested ref was resolved.
+type RefKind string
+
+const (
+	RefTag     RefKind = "tag"
+	RefBranch  RefKind = "branch"
+	RefCommit  RefKind = "commit"
+	RefDefault RefKind = "default" // untagged repo, default branch tip
+)
+
+// Resolution is the commit a requested ref points at.
+type Resolution struct {
+	Kind   RefKind
+	Name   string // tag or branch name; empty for commits
+	Commit plumbing.Hash
+	// Version is set for semver tags.
+	Version *semver.Version
+}
+
+// Resolve maps a ref requested by the user to a commit. In order it tries:
+// "" or "latest" (newest release tag, or the default branch when the repo
+// has no release tags), a semver version or range ("1.2.3", "^1.2",
+// ">=1.0 <2.0") matched against tags, a branch, any other tag, and a full or
+// abbreviated commit SHA. Pre-release tags are only picked when includePre
+// is set or the range names a pre-release.
+func Resolve(repo *git.Repository, ref string, includePre bool) (Resolution, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.EqualFold(ref, "latest") {
+		c, _ := semver.ParseConstraint("")
+		if res, ok, err := newestTag(repo, c, includePre); err != nil || ok {
+			return res, err
+		}
+		return defaultBranch(repo)
+	}
+
+	c, constraintErr := semver.ParseConstraint(ref)
+	if constraintErr == nil {
+		res, ok, err := newestTag(repo, c, includePre)
+		if err != nil || ok {
+			return res, err
+		}
+		// All-digit refs parse as versions but may still be abbreviated SHAs.
+		if !looksLikeSHA(ref) {
+			return Resolution{}, fmt.Errorf("no tag satisfies %q", ref)
+		}
+	}
+
+	if h, err := repo.ResolveRevision(plumbing.Revision("refs/remotes/origin/" + ref)); err == nil {
+		return Resolution{Kind: RefBranch, Name: ref, Commit: *h}, nil
+	}
+	if h, err := repo.ResolveRevision(plumbing.Revision("refs/heads/" + ref)); err == nil {
+		return Resolution{Kind: RefBranch, Name: ref, Commit: *h}, nil
+	}
+	if h, err := repo.ResolveRevision(plumbing.Revision("refs/tags/" + ref)); err == nil {
+		return Resolution{Kind: RefTag, Name: ref, Commit: *h}, nil
+	}
+	if looksLikeSHA(ref) {
+		if h, err := findCommit(repo, strings.ToLower(ref)); err == nil {
+			return Resolution{Kind: RefCommit, Commit: h}, nil
+		} else if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return Resolution{}, err
+		}
+	}
+	if constraintErr == nil {
+		return Resolution{}, fmt.Errorf("no tag satisfies %q", ref)
+	}
+	return Resolution{}, fmt.Errorf("%q is not a version, range, branch, tag or commit", ref)
+}
+
+// newestTag returns the highest semver tag satisfying c.
+func newestTag(repo *git.Repository, c semver.Constraint, includePre bool) (Resolution, bool, error) {
+	if includePre {
+		c = c.WithPrerelease()
+	}
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return Resolution{}, false, fmt.Errorf("failed to get tags: %w", err)
+	}
+	var best *plumbing.Reference
+	var bestVersion semver.Version
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		v, err := semver.Parse(ref.Name().Short())
+		if err != nil || !c.Check(v) {
+			return nil // Ignore invalid and non-matching tags
+		}
+		if best == nil || v.Compare(bestVersion) > 0 {
+			best, bestVersion = ref, v
+		}
+		return nil
+	})
+	if err != nil {
+		return Resolution{}, false, fmt.Errorf("failed to iterate over tags: %w", err)
+	}
+	if best == nil {
+		return Resolution{}, false, nil
+	}
+	// Annotated tags point at a tag object; resolve to the commit.
+	h, err := repo.ResolveRevision(plumbing.Revision(best.Name().String()))
+	if err != nil {
+		return Resolution{}, false, fmt.Errorf("failed to resolve %s: %w", best.Name().Short(), err)
+	}
+	return Resolution{Kind: RefTag, Name: best.Name().Short(), Commit: *h, Version: &bestVersion}, true, nil
+}
+
+// defaultBranch returns the tip of the remote default branch, preferring it
+// over a possibly stale checkout.
+func defaultBranch(repo *git.Repository) (Resolution, error) {
+	for _, rev := range []string{"refs/remotes/origin/HEAD", "refs/remotes/origin/main", "refs/remotes/origin/master", "HEAD"} {
+		if h, err := repo.ResolveRevision(plumbing.Revision(rev)); err == nil {
+			return Resolution{Kind: RefDefault, Commit: *h}, nil
+		}
+	}
+	return Resolution{}, errors.New("failed to resolve the default branch")
+}
+
+func looksLikeSHA(s string) bool {
+	if len(s) < 4 || len(s) > 40 {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// findCommit resolves a full or unambiguous abbreviated commit SHA.
+func findCommit(repo *git.Repository, prefix string) (plumbing.Hash, error) {
+	if len(prefix) == 40 {
+		h := plumbing.NewHash(prefix)
+		if _, err := repo.CommitObject(h); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return h, nil
+	}
+	iter, err := repo.CommitObjects()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	var found []plumbing.Hash
+	err = iter.ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), prefix) {
+			found = append(found, c.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	switch len(found) {
+	case 0:
+		return plumbing.ZeroHash, plumbing.ErrObjectNotFound
+	case 1:
+		return found[0], nil
+	}
+	return plumbing.ZeroHash, fmt.Errorf("commit %s is ambiguous", prefix)
+}
+
+// Checkout force-checks out hash, discarding local edits to the tree.
+func Checkout(repo *git.Repository, hash plumbing.Hash) error {
+	w, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return w.Checkout(&git.CheckoutOptions{Hash: hash, Force: true})
+}
+
+// String describes the resolution for log output.
+func (r Resolution) String() string {
+	short := r.Commit.String()[:7]
+	switch r.Kind {
+	case RefTag:
+		return fmt.Sprintf("tag %s (%s)", r.Name, short)
+	case RefBranch:
+		return fmt.Sprintf("branch %s (%s)", r.Name, short)
+	case RefDefault:
+		return fmt.Sprintf("default branch (%s)", short)
+	}
+	return "commit " + short
+}
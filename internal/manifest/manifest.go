@@ -0,0 +1,182 @@
+// Package manifest reads uag.yaml/uag.json project manifests, which declare
+// the plugins a machine should have, and the uag.lock file that pins them.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames lists the manifest names looked up in a directory, in order.
+var FileNames = []string{"uag.yaml", "uag.yml", "uag.json"}
+
+// LockFileName is written next to the manifest.
+const LockFileName = "uag.lock"
+
+// Plugin declares one plugin. Exactly one of Repo or Dir must be set.
+type Plugin struct {
+	Name string `yaml:"name" json:"name"`
+	// Repo is a repository URL; Version constrains its tags (e.g. "^1.2").
+	Repo    string `yaml:"repo,omitempty" json:"repo,omitempty"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+	// Dir is a local plugin directory, relative to the manifest.
+	Dir     string         `yaml:"dir,omitempty" json:"dir,omitempty"`
+	Runtime host.Transport `yaml:"runtime,omitempty" json:"runtime,omitempty"`
+}
+
+// Manifest is the parsed content of uag.yaml or uag.json.
+type Manifest struct {
+	Plugins []Plugin `yaml:"plugins" json:"plugins"`
+
+	// Path is the file the manifest was read from.
+	Path string `yaml:"-" json:"-"`
+}
+
+// Find locates the manifest in dir.
+func Find(dir string) (string, error) {
+	for _, name := range FileNames {
+		p := filepath.Join(dir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no manifest (%s) found in %s", strings.Join(FileNames, ", "), dir)
+}
+
+// Load reads and validates the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m := &Manifest{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, m)
+	} else {
+		err = yaml.Unmarshal(data, m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	m.Path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+func (m *Manifest) validate() error {
+	seen := map[string]bool{}
+	for i := range m.Plugins {
+		p := &m.Plugins[i]
+		if p.Name == "" {
+			return fmt.Errorf("plugin #%d has no name", i+1)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("plugin %q is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+		if (p.Repo == "") == (p.Dir == "") {
+			return fmt.Errorf("plugin %q must set exactly one of repo or dir", p.Name)
+		}
+		if p.Dir != "" && p.Version != "" {
+			return fmt.Errorf("plugin %q: version only applies to repo plugins", p.Name)
+		}
+		if _, err := semver.ParseConstraint(p.Version); err != nil {
+			return fmt.Errorf("plugin %q: %w", p.Name, err)
+		}
+		switch p.Runtime {
+		case "":
+			p.Runtime = host.TransportNative
+		case host.TransportNative, host.TransportRPC:
+		default:
+			return fmt.Errorf("plugin %q: unknown runtime %q", p.Name, p.Runtime)
+		}
+	}
+	return nil
+}
+
+// DirPath resolves p.Dir against the manifest location.
+func (m *Manifest) DirPath(p Plugin) string {
+	if filepath.IsAbs(p.Dir) {
+		return p.Dir
+	}
+	return filepath.Join(filepath.Dir(m.Path), p.Dir)
+}
+
+// LockPath returns the lock file belonging to the manifest.
+func (m *Manifest) LockPath() string {
+	return filepath.Join(filepath.Dir(m.Path), LockFileName)
+}
+
+// LockedPlugin pins a manifest plugin to an exact build.
+type LockedPlugin struct {
+	Name       string         `json:"name"`
+	SourceType string         `json:"source_type"`
+	Source     string         `json:"source"`
+	Constraint string         `json:"constraint,omitempty"`
+	Tag        string         `json:"tag,omitempty"`
+	Commit     string         `json:"commit,omitempty"`
+	Runtime    host.Transport `json:"runtime"`
+	Checksum   string         `json:"checksum"`
+}
+
+// Lock is the content of uag.lock.
+type Lock struct {
+	Version int            `json:"version"`
+	Plugins []LockedPlugin `json:"plugins"`
+}
+
+const lockVersion = 1
+
+// LoadLock reads the lock at path; a missing file yields an empty lock.
+func LoadLock(path string) (*Lock, error) {
+	l := &Lock{Version: lockVersion}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return l, nil
+		}
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	if l.Version != lockVersion {
+		return nil, fmt.Errorf("unsupported lock file version %d in %s", l.Version, path)
+	}
+	return l, nil
+}
+
+// Get returns the pinned entry for name.
+func (l *Lock) Get(name string) (LockedPlugin, bool) {
+	for _, p := range l.Plugins {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return LockedPlugin{}, false
+}
+
+// Save writes the lock sorted by plugin name so diffs stay stable.
+func (l *Lock) Save(path string) error {
+	l.Version = lockVersion
+	slices.SortFunc(l.Plugins, func(a, b LockedPlugin) int { return strings.Compare(a.Name, b.Name) })
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,130 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should load yaml manifests and apply defaults", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "uag.yaml", `
+plugins:
+  - name: api
+    repo: github.com/org/uag-apiplugin
+    version: ^1.2
+  - name: file
+    dir: ./plugins/file
+    runtime: rpc
+`)
+		path, err := Find(dir)
+		if err != nil {
+			t.Fatalf("Expected manifest to be found, got %v", err)
+		}
+		m, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(m.Plugins) != 2 {
+			t.Fatalf("Expected 2 plugins, got %d", len(m.Plugins))
+		}
+		if m.Plugins[0].Runtime != host.TransportNative {
+			t.Errorf("Expected default runtime native, got %s", m.Plugins[0].Runtime)
+		}
+		if got := m.DirPath(m.Plugins[1]); got != filepath.Join(dir, "plugins", "file") {
+			t.Errorf("Expected dir relative to manifest, got %s", got)
+		}
+		if m.LockPath() != filepath.Join(dir, LockFileName) {
+			t.Errorf("Expected lock next to manifest, got %s", m.LockPath())
+		}
+	})
+
+	t.Run("should load json manifests", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeFile(t, dir, "uag.json", `{"plugins":[{"name":"api","repo":"github.com/org/api","version":"~1.0.0"}]}`)
+		m, err := Load(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if m.Plugins[0].Version != "~1.0.0" {
+			t.Errorf("Expected version ~1.0.0, got %s", m.Plugins[0].Version)
+		}
+	})
+
+	t.Run("should reject invalid declarations", func(t *testing.T) {
+		cases := map[string]string{
+			"missing name":    `{"plugins":[{"repo":"github.com/o/r"}]}`,
+			"duplicate":       `{"plugins":[{"name":"a","dir":"x"},{"name":"a","dir":"y"}]}`,
+			"repo and dir":    `{"plugins":[{"name":"a","repo":"github.com/o/r","dir":"x"}]}`,
+			"neither":         `{"plugins":[{"name":"a"}]}`,
+			"dir version":     `{"plugins":[{"name":"a","dir":"x","version":"^1"}]}`,
+			"bad constraint":  `{"plugins":[{"name":"a","repo":"github.com/o/r","version":"^x"}]}`,
+			"unknown runtime": `{"plugins":[{"name":"a","dir":"x","runtime":"wasm"}]}`,
+		}
+		for name, content := range cases {
+			path := writeFile(t, t.TempDir(), "uag.json", content)
+			if _, err := Load(path); err == nil {
+				t.Errorf("Expected error for %s", name)
+			}
+		}
+	})
+
+	t.Run("should report a missing manifest", func(t *testing.T) {
+		if _, err := Find(t.TempDir()); err == nil {
+			t.Errorf("Expected error for missing manifest")
+		}
+	})
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockFileName)
+
+	t.Run("should load an empty lock when missing", func(t *testing.T) {
+		l, err := LoadLock(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(l.Plugins) != 0 {
+			t.Errorf("Expected no plugins, got %d", len(l.Plugins))
+		}
+	})
+
+	t.Run("should round trip sorted by name", func(t *testing.T) {
+		l := &Lock{Plugins: []LockedPlugin{
+			{Name: "b", SourceType: "dir", Source: "./b", Runtime: host.TransportRPC, Checksum: "2"},
+			{Name: "a", SourceType: "repo", Source: "https://github.com/o/a", Tag: "v1.0.0", Commit: "abc", Runtime: host.TransportNative, Checksum: "1"},
+		}}
+		if err := l.Save(path); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		loaded, err := LoadLock(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if loaded.Plugins[0].Name != "a" || loaded.Plugins[1].Name != "b" {
+			t.Errorf("Expected plugins sorted by name, got %+v", loaded.Plugins)
+		}
+		if p, ok := loaded.Get("a"); !ok || p.Commit != "abc" || p.Tag != "v1.0.0" {
+			t.Errorf("Expected pinned commit for a, got %+v", p)
+		}
+	})
+
+	t.Run("should reject unknown lock versions", func(t *testing.T) {
+		bad := writeFile(t, t.TempDir(), LockFileName, `{"version":99,"plugins":[]}`)
+		if _, err := LoadLock(bad); err == nil {
+			t.Errorf("Expected error for unsupported version")
+		}
+	})
+}
@@ -0,0 +1,243 @@
+// Package output renders plugin results as JSON, aligned tables or CSV.
+//
+// Tables and CSV are derived from JSON field names, so new model fields show
+// up without changes here: a result wrapping a list (models.Contacts,
+// models.Ledger) becomes one row per item, other structs and maps become
+// key/value rows.
+package output
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	JSON  Format = "json"
+	Table Format = "table"
+	CSV   Format = "csv"
+)
+
+// ParseFormat validates an --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case JSON, Table, CSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q (choose json, table or csv)", s)
+}
+
+// Write renders v to w in format f.
+func Write(w io.Writer, v any, f Format) error {
+	switch f {
+	case Table:
+		return writeTable(w, v)
+	case CSV:
+		return writeCSV(w, v)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeTable(w io.Writer, v any) error {
+	header, rows, extra := Rows(v)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(extra) > 0 {
+		fmt.Fprintln(w)
+		for _, kv := range extra {
+			fmt.Fprintf(w, "%s: %s\n", kv[0], kv[1])
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, v any) error {
+	header, rows, _ := Rows(v)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// Rows flattens v into a header and rows. For structs wrapping a list, extra
+// holds the remaining non-empty fields (count, total, next_cursor, ...).
+func Rows(v any) (header []string, rows [][]string, extra [][2]string) {
+	rv := deref(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return []string{"value"}, nil, nil
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		fields := structFields(rv)
+		if list := listField(fields); list >= 0 {
+			for i, f := range fields {
+				if i != list && !f.value.IsZero() {
+					extra = append(extra, [2]string{f.name, cell(f.value)})
+				}
+			}
+			header, rows = listRows(fields[list].value)
+			return header, rows, extra
+		}
+		for _, f := range fields {
+			rows = append(rows, []string{f.name, cell(f.value)})
+		}
+		return []string{"field", "value"}, rows, nil
+	case reflect.Slice, reflect.Array:
+		header, rows = listRows(rv)
+		return header, rows, nil
+	case reflect.Map:
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(cell(a), cell(b)) })
+		for _, k := range keys {
+			rows = append(rows, []string{cell(k), cell(rv.MapIndex(k))})
+		}
+		return []string{"key", "value"}, rows, nil
+	}
+	return []string{"value"}, [][]string{{cell(rv)}}, nil
+}
+
+type field struct {
+	name  string
+	value reflect.Value
+}
+
+// structFields lists exported fields by JSON name, flattening embedded structs.
+func structFields(rv reflect.Value) []field {
+	var out []field
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		if sf.Anonymous && tag == "" && deref(fv).Kind() == reflect.Struct {
+			out = append(out, structFields(deref(fv))...)
+			continue
+		}
+		if tag == "" {
+			tag = sf.Name
+		}
+		out = append(out, field{name: tag, value: fv})
+	}
+	return out
+}
+
+// listField returns the index of the only slice-of-structs field, or -1.
+func listField(fields []field) int {
+	found := -1
+	for i, f := range fields {
+		t := f.value.Type()
+		if t.Kind() != reflect.Slice {
+			continue
+		}
+		if e := t.Elem(); e.Kind() == reflect.Struct || e.Kind() == reflect.Pointer && e.Elem().Kind() == reflect.Struct {
+			if found >= 0 {
+				return -1
+			}
+			found = i
+		}
+	}
+	return found
+}
+
+func listRows(rv reflect.Value) (header []string, rows [][]string) {
+	elem := rv.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		for i := 0; i < rv.Len(); i++ {
+			rows = append(rows, []string{cell(rv.Index(i))})
+		}
+		return []string{"value"}, rows
+	}
+	for _, f := range structFields(reflect.New(elem).Elem()) {
+		header = append(header, f.name)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		item := deref(rv.Index(i))
+		var row []string
+		if item.IsValid() {
+			for _, f := range structFields(item) {
+				row = append(row, cell(f.value))
+			}
+		} else {
+			row = make([]string, len(header))
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// cell formats a single value: text marshalers as text, strings raw, lists
+// joined with ";" and anything else as compact JSON.
+func cell(v reflect.Value) string {
+	if v.IsValid() && v.CanInterface() {
+		if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+			if v.Kind() != reflect.Pointer || !v.IsNil() {
+				if b, err := tm.MarshalText(); err == nil {
+					return string(b)
+				}
+			}
+		}
+	}
+	v = deref(v)
+	if !v.IsValid() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			parts := make([]string, v.Len())
+			for i := range parts {
+				parts[i] = cell(v.Index(i))
+			}
+			return strings.Join(parts, ";")
+		}
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(b)
+}
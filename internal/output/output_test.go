@@ -0,0 +1,80 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func TestWrite(t *testing.T) {
+	next := "Mg=="
+	contacts := &models.Contacts{
+		Items: []models.Contact{
+			{ID: "1", Name: "Acme, Inc.", Email: "ap@acme.test"},
+			{ID: "2", Name: "Globex"},
+		},
+		Count:      2,
+		Total:      3,
+		NextCursor: &next,
+	}
+
+	t.Run("should write one CSV row per item", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, contacts, CSV); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		empty := strings.Repeat(",", 10)
+		want := "id,name,email,company_name,phones,billing_address,shipping_address,tax_id,currency,outstanding_balance,created_at,updated_at,attributes\n" +
+			"1,\"Acme, Inc.\",ap@acme.test" + empty + "\n2,Globex," + empty + "\n"
+		if buf.String() != want {
+			t.Errorf("Expected %q, got %q", want, buf.String())
+		}
+	})
+
+	t.Run("should write a table with the page summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, contacts, Table); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if !strings.HasPrefix(lines[0], "ID  NAME") {
+			t.Errorf("Expected header row, got %q", lines[0])
+		}
+		if !strings.Contains(buf.String(), "next_cursor: Mg==") || !strings.Contains(buf.String(), "total: 3") {
+			t.Errorf("Expected page summary, got %q", buf.String())
+		}
+	})
+
+	t.Run("should write structs as key/value rows", func(t *testing.T) {
+		header, rows, _ := Rows(&models.MetaData{ID: "demo", AuthType: models.AuthTypeNone})
+		if header[0] != "field" || rows[0][0] != "id" || rows[0][1] != "demo" {
+			t.Errorf("Expected id row, got %v %v", header, rows)
+		}
+	})
+
+	t.Run("should format ledger entries with raw doc types", func(t *testing.T) {
+		balance := models.MustParseMoney("-1.50")
+		_, rows, _ := Rows(&models.Ledger{Entries: []models.LedgerEntry{{ID: 7, DocType: models.DocTypeCreditNote, Amount: models.MustParseMoney("1.50"), Direction: models.DirectionCredit, Balance: &balance}}})
+		if got := strings.Join(rows[0], ","); got != "7,,credit_note,1.50,,,,credit,,-1.50," {
+			t.Errorf("Expected 7,,credit_note,1.50,,,,credit,,-1.50,, got %s", got)
+		}
+	})
+
+	t.Run("should sort map keys", func(t *testing.T) {
+		_, rows, _ := Rows(map[string]string{"b": "2", "a": "1"})
+		if rows[0][0] != "a" || rows[1][0] != "b" {
+			t.Errorf("Expected sorted keys, got %v", rows)
+		}
+	})
+
+	t.Run("should reject unknown formats", func(t *testing.T) {
+		if _, err := ParseFormat("yaml"); err == nil {
+			t.Errorf("Expected error for yaml")
+		}
+		if f, _ := ParseFormat("CSV"); f != CSV {
+			t.Errorf("Expected csv, got %q", f)
+		}
+	})
+}
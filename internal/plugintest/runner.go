@@ -3,68 +3,110 @@ package plugintest
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"plugin"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/conformance"
+	"github.com/nikhiljohn10/uagplugin/internal/credstore"
 	"github.com/nikhiljohn10/uagplugin/logger"
 	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
 	"github.com/nikhiljohn10/uagplugin/typing"
 )
 
 type Mode string
 
 const (
-	ModeSmoke  Mode = "smoke"
-	ModeSource Mode = "source"
-	ModeAll    Mode = "all"
+	ModeSmoke       Mode = "smoke"
+	ModeSource      Mode = "source"
+	ModeAll         Mode = "all"
+	ModeConformance Mode = "conformance"
 )
 
+// conformanceRuleTimeoutFactor bounds a whole conformance rule, which makes
+// several plugin calls each bounded by RunConfig.Timeout.
+const conformanceRuleTimeoutFactor = 20
+
 func ModeFromString(s string) Mode {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case string(ModeSource):
 		return ModeSource
 	case string(ModeAll):
 		return ModeAll
+	case string(ModeConformance):
+		return ModeConformance
 	default:
 		return ModeSmoke
 	}
 }
 
 type RunConfig struct {
-	BaseDir       string
-	BuildDir      string // legacy fallback
-	Name          string // legacy filter (deprecated)
-	Files         []string
-	SearchDirs    []string
-	Timeout       time.Duration
-	Mode          Mode
-	Auth          models.AuthCredentials
+	BaseDir    string
+	BuildDir   string   // legacy fallback
+	Name       string   // legacy filter (deprecated)
+	Files      []string // plugin files or remote targets (grpc://host:port/name)
+	SearchDirs []string
+	Timeout    time.Duration
+	Mode       Mode
+	Auth       models.AuthCredentials
+	// AuthParams is passed to Auth for plugins implementing typing.Authenticator.
+	AuthParams    models.AuthParams
 	ContactParams models.ContactQueryParams
 	LedgerParams  models.LedgerQueryParams
-	JSON          bool
+	// Tokens holds OAuth2 tokens from `uagplugin auth`; oauth2 plugins get
+	// theirs, refreshed if needed, merged under each scenario's Auth.
+	Tokens host.TokenStore
+	// Profile names the stored credentials (`uagplugin creds`) loaded from
+	// Credentials for each plugin and merged under each scenario's Auth.
+	Profile     string
+	Credentials *credstore.Store
+	// ScenarioFile overrides the uagtest.yaml looked up per plugin.
+	ScenarioFile string
+	JSON         bool
 }
 
 type FuncResult struct {
-	Name    string        `json:"name"`
-	Status  string        `json:"status"` // ok|missing|error|timeout|panic|skipped
-	Error   string        `json:"error,omitempty"`
-	Elapsed time.Duration `json:"elapsed_ms"`
+	Name string `json:"name"`
+	// Scenario names the uagtest.yaml scenario a call ran under.
+	Scenario string        `json:"scenario,omitempty"`
+	Status   string        `json:"status"` // ok|missing|error|timeout|panic|skipped
+	Error    string        `json:"error,omitempty"`
+	Panic    string        `json:"panic,omitempty"`
+	Stack    string        `json:"stack,omitempty"`
+	Elapsed  time.Duration `json:"elapsed_ms"`
 }
 
 type PluginResult struct {
-	Name       string       `json:"name"`
-	File       string       `json:"file"`
-	Funcs      []FuncResult `json:"funcs"`
-	SourceTest *FuncResult  `json:"source_test,omitempty"`
+	Name       string         `json:"name"`
+	File       string         `json:"file"`
+	Transport  host.Transport `json:"transport,omitempty"`
+	Failed     bool           `json:"failed"`
+	Funcs      []FuncResult   `json:"funcs"`
+	SourceTest *FuncResult    `json:"source_test,omitempty"`
+}
+
+// PanicSummary identifies a call that panicked during the run.
+type PanicSummary struct {
+	Plugin string `json:"plugin"`
+	File   string `json:"file"`
+	Func   string `json:"func"`
+	// Scenario is set when the call ran under a uagtest.yaml scenario.
+	Scenario string `json:"scenario,omitempty"`
+	Value    string `json:"value"`
 }
 
 type RunResult struct {
 	Plugins  []PluginResult `json:"plugins"`
+	Panics   []PanicSummary `json:"panics,omitempty"`
 	Failures int            `json:"failures"`
 }
 
@@ -77,7 +119,12 @@ func Run(ctx context.Context, cfg RunConfig) RunResult {
 		if f == "" {
 			continue
 		}
-		if !strings.HasSuffix(strings.ToLower(f), ".so") {
+		// Remote targets (grpc://host:port/name) are opened as given.
+		if host.IsRemote(f) {
+			fileSet[f] = struct{}{}
+			continue
+		}
+		if !host.IsPluginFile(f) {
 			continue
 		}
 		if abs, err := filepath.Abs(f); err == nil {
@@ -98,17 +145,15 @@ func Run(ctx context.Context, cfg RunConfig) RunResult {
 					abs = a
 				}
 			}
-			matches, _ := filepath.Glob(filepath.Join(abs, "*.so"))
-			for _, m := range matches {
+			for _, m := range globPlugins(abs) {
 				fileSet[m] = struct{}{}
 			}
 		}
 	}
 	// Legacy fallback to BuildDir + Name filter
 	if len(fileSet) == 0 && cfg.BuildDir != "" {
-		matches, _ := filepath.Glob(filepath.Join(cfg.BuildDir, "*.so"))
-		for _, f := range matches {
-			base := strings.TrimSuffix(filepath.Base(f), ".so")
+		for _, f := range globPlugins(cfg.BuildDir) {
+			base := host.PluginName(f)
 			if cfg.Name == "" || strings.EqualFold(cfg.Name, base) {
 				fileSet[f] = struct{}{}
 			}
@@ -118,13 +163,17 @@ func Run(ctx context.Context, cfg RunConfig) RunResult {
 		list = append(list, f)
 	}
 	sort.Strings(list)
-	// Deduplicate by plugin basename to avoid loading logically identical plugins
+	// Deduplicate by plugin file name to avoid loading logically identical plugins
 	// from multiple locations (which can trigger "plugin already loaded").
+	// The same plugin built for both transports is tested once per transport.
 	{
 		seen := map[string]bool{}
 		filtered := make([]string, 0, len(list))
 		for _, f := range list {
-			base := strings.TrimSuffix(filepath.Base(f), ".so")
+			base := filepath.Base(f)
+			if host.IsRemote(f) {
+				base = f
+			}
 			if seen[base] {
 				continue
 			}
@@ -144,10 +193,13 @@ func Run(ctx context.Context, cfg RunConfig) RunResult {
 			return res
 		}
 		pr := testOne(ctx, f, cfg)
-		res.Plugins = append(res.Plugins, pr)
 		for _, fr := range pr.Funcs {
 			if fr.Status != "ok" && fr.Status != "missing" && fr.Status != "skipped" {
 				res.Failures++
+				pr.Failed = true
+			}
+			if fr.Status == "panic" {
+				res.Panics = append(res.Panics, PanicSummary{Plugin: pr.Name, File: pr.File, Func: fr.Name, Scenario: fr.Scenario, Value: fr.Panic})
 			}
 		}
 		if pr.SourceTest != nil {
@@ -157,133 +209,212 @@ func Run(ctx context.Context, cfg RunConfig) RunResult {
 				// not a failure
 			default:
 				res.Failures++
+				pr.Failed = true
 			}
 		}
+		res.Plugins = append(res.Plugins, pr)
 	}
 	return res
 }
 
-func testOne(ctx context.Context, file string, cfg RunConfig) PluginResult {
-	base := strings.TrimSuffix(filepath.Base(file), ".so")
-	pr := PluginResult{Name: base, File: file}
+func testOne(ctx context.Context, file string, cfg RunConfig) (pr PluginResult) {
+	base := host.PluginName(file)
+	pr = PluginResult{Name: base, File: file}
 
-	// Open plugin
+	// A panic outside an individual call (e.g. in a plugin's init during
+	// plugin.Open) must not take down the whole run.
+	defer func() {
+		if r := recover(); r != nil {
+			pr.Funcs = append(pr.Funcs, panicResult("Load", r, debug.Stack()))
+		}
+	}()
+
+	scenarios, err := scenariosFor(cfg, base)
+	if err != nil {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Scenarios", Status: "error", Error: err.Error()})
+		return pr
+	}
+	if cfg.Profile != "" && cfg.Credentials != nil {
+		scenarios = withStoredCredentials(cfg, &pr, scenarios)
+	}
+
+	// Open plugin; the typed interface is preferred for every transport.
+	hp, err := host.Open(ctx, file)
+	if err == nil {
+		defer hp.Close()
+		pr.Transport = hp.Transport
+		testTyped(ctx, hp, &pr, cfg, scenarios)
+		return pr
+	}
+	if !errors.Is(err, host.ErrNoPluginSymbol) {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Open", Status: "error", Error: err.Error()})
+		return pr
+	}
+
+	// Shared objects without a typed Plugin symbol fall back to legacy top-level functions.
+	pr.Transport = host.TransportNative
 	p, err := plugin.Open(file)
 	if err != nil {
 		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Open", Status: "error", Error: err.Error()})
 		return pr
 	}
+	testLegacy(ctx, p, &pr, cfg, scenarios)
+	return pr
+}
+
+func testTyped(ctx context.Context, impl *host.Plugin, pr *PluginResult, cfg RunConfig, scenarios []Scenario) {
+	wrap := func(name string, f func(ctx context.Context) FuncResult) FuncResult {
+		return invoke(ctx, cfg.Timeout, name, f)
+	}
 
-	// First try the typed interface: Lookup("Plugin") of type typing.Plugin
-	if sym, err := p.Lookup("Plugin"); err == nil && sym != nil {
-		// Symbol can be the interface value directly, or a pointer to it.
-		var impl typing.Plugin
-		switch v := sym.(type) {
-		case typing.Plugin:
-			impl = v
-		case *typing.Plugin:
-			if v != nil {
-				impl = *v
+	// Compatibility check using Meta()["contract_version"] if present
+	var meta *models.MetaData
+	metaRes := wrap("Meta", func(ctx context.Context) FuncResult {
+		var err error
+		if meta, err = impl.MetaContext(ctx); err != nil {
+			return FuncResult{Name: "Meta", Status: "error", Error: err.Error()}
+		}
+		return FuncResult{Name: "Meta", Status: "ok"}
+	})
+	if metaRes.Status != "ok" {
+		pr.Funcs = append(pr.Funcs, metaRes)
+		return
+	}
+	if meta != nil && meta.ContractVersion != "" && !typing.IsCompatible(meta.ContractVersion) {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Contract", Status: "error", Error: typing.IncompatibilityMessage(meta.ContractVersion)})
+		return
+	}
+
+	// Meta
+	pr.Funcs = append(pr.Funcs, metaRes)
+	if err := host.ValidateAuthType(meta); err != nil {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "AuthType", Status: "error", Error: err.Error()})
+	}
+	if meta != nil && meta.AuthType == models.AuthTypeOAuth2 && cfg.Tokens != nil {
+		scenarios = withOAuth2Token(ctx, cfg, pr, meta, scenarios)
+	}
+	// Health
+	pr.Funcs = append(pr.Funcs, wrap("Health", func(ctx context.Context) FuncResult {
+		_ = impl.HealthContext(ctx)
+		return FuncResult{Name: "Health", Status: "ok"}
+	}))
+	// Optional RunTests
+	if _, ok := impl.Tester(); ok {
+		pr.Funcs = append(pr.Funcs, wrap("RunTests", func(ctx context.Context) FuncResult {
+			if err := impl.RunTestsContext(ctx); err != nil {
+				return errorResult("RunTests", err)
+			}
+			return FuncResult{Name: "RunTests", Status: "ok"}
+		}))
+	} else {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "RunTests", Status: "skipped"})
+	}
+	// Optional Auth; its credentials feed the scenario's data calls
+	_, hasAuth := impl.Authenticator()
+	if !hasAuth {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Auth", Status: "skipped"})
+	}
+	for _, sc := range scenarios {
+		if hasAuth {
+			var ok bool
+			if sc, ok = runAuth(ctx, cfg, pr, sc, func(ctx context.Context) (*models.AuthCredentials, error) {
+				return impl.AuthenticateContext(ctx, sc.AuthParams)
+			}); !ok {
+				continue
 			}
 		}
-		if impl != nil {
-			// Compatibility check using Meta()["contract_version"] if present
-			if meta := impl.Meta(); meta != nil {
-				if meta.ContractVersion != "" && !typing.IsCompatible(meta.ContractVersion) {
-					pr.Funcs = append(pr.Funcs, FuncResult{Name: "Contract", Status: "error", Error: typing.IncompatibilityMessage(meta.ContractVersion)})
-					return pr
-				}
+		// Contacts
+		pr.Funcs = append(pr.Funcs, inScenario(sc, wrap("Contacts", func(ctx context.Context) FuncResult {
+			if _, err := impl.ContactsContext(ctx, sc.Auth, sc.ContactParams); err != nil {
+				return errorResult("Contacts", err)
 			}
-			// Helper invoker using ctx timeout wrapper
-			wrap := func(name string, f func() FuncResult) FuncResult {
-				start := time.Now()
-				done := make(chan FuncResult, 1)
-				go func() { done <- f() }()
-				select {
-				case r := <-done:
-					r.Elapsed = time.Since(start)
-					return r
-				case <-ctx.Done():
-					return FuncResult{Name: name, Status: "timeout", Elapsed: time.Since(start)}
-				case <-time.After(cfg.Timeout):
-					return FuncResult{Name: name, Status: "timeout", Elapsed: time.Since(start)}
-				}
+			return FuncResult{Name: "Contacts", Status: "ok"}
+		})))
+		// Ledger (now core)
+		pr.Funcs = append(pr.Funcs, inScenario(sc, wrap("Ledger", func(ctx context.Context) FuncResult {
+			if err := sc.LedgerParams.Validate(); err != nil {
+				return FuncResult{Name: "Ledger", Status: "error", Error: "invalid ledger params: " + err.Error()}
 			}
-
-			// Meta
-			pr.Funcs = append(pr.Funcs, wrap("Meta", func() FuncResult {
-				_ = impl.Meta()
-				return FuncResult{Name: "Meta", Status: "ok"}
-			}))
-			// Health
-			pr.Funcs = append(pr.Funcs, wrap("Health", func() FuncResult {
-				_ = impl.Health()
-				return FuncResult{Name: "Health", Status: "ok"}
-			}))
-			// Optional RunTests
-			if t, ok := any(impl).(typing.Tester); ok {
-				pr.Funcs = append(pr.Funcs, wrap("RunTests", func() FuncResult {
-					if err := t.RunTests(); err != nil {
-						return FuncResult{Name: "RunTests", Status: "error", Error: err.Error()}
-					}
-					return FuncResult{Name: "RunTests", Status: "ok"}
-				}))
-			} else {
-				pr.Funcs = append(pr.Funcs, FuncResult{Name: "RunTests", Status: "skipped"})
+			l, err := impl.LedgerContext(ctx, sc.Auth, sc.LedgerParams)
+			if err != nil {
+				return errorResult("Ledger", err)
 			}
-			// Contacts
-			pr.Funcs = append(pr.Funcs, wrap("Contacts", func() FuncResult {
-				if _, err := impl.Contacts(cfg.Auth, cfg.ContactParams); err != nil {
-					return FuncResult{Name: "Contacts", Status: "error", Error: err.Error()}
-				}
-				return FuncResult{Name: "Contacts", Status: "ok"}
-			}))
-			// Ledger (now core)
-			pr.Funcs = append(pr.Funcs, wrap("Ledger", func() FuncResult {
-				if _, err := impl.Ledger(cfg.Auth, cfg.LedgerParams); err != nil {
-					return FuncResult{Name: "Ledger", Status: "error", Error: err.Error()}
-				}
-				return FuncResult{Name: "Ledger", Status: "ok"}
-			}))
+			if err := checkLedger(l, sc.LedgerParams); err != nil {
+				return FuncResult{Name: "Ledger", Status: "error", Error: err.Error()}
+			}
+			return FuncResult{Name: "Ledger", Status: "ok"}
+		})))
 
-			// Source tests
-			if cfg.Mode == ModeSource || cfg.Mode == ModeAll {
-				st := runSourceTests(ctx, cfg.BaseDir, base)
-				pr.SourceTest = &st
+		// Conformance rules
+		if cfg.Mode == ModeConformance {
+			for _, r := range runConformance(ctx, impl, cfg, sc) {
+				pr.Funcs = append(pr.Funcs, inScenario(sc, r))
 			}
-			return pr
 		}
 	}
 
+	// Source tests
+	if cfg.Mode == ModeSource || cfg.Mode == ModeAll {
+		st := runSourceTests(ctx, cfg.BaseDir, pr.Name)
+		pr.SourceTest = &st
+	}
+}
+
+// runConformance evaluates every conformance rule, reporting each as its own result.
+func runConformance(ctx context.Context, impl *host.Plugin, cfg RunConfig, sc Scenario) []FuncResult {
+	suite := conformance.NewSuite(impl, conformance.Config{
+		Auth:          sc.Auth,
+		ContactParams: sc.ContactParams,
+		LedgerParams:  sc.LedgerParams,
+		CallTimeout:   cfg.Timeout,
+	})
+	var out []FuncResult
+	for _, rule := range conformance.Rules {
+		name := "conformance/" + rule.Name
+		out = append(out, invoke(ctx, cfg.Timeout*conformanceRuleTimeoutFactor, name, func(ctx context.Context) FuncResult {
+			err := suite.Run(ctx, rule)
+			if err == nil {
+				return FuncResult{Name: name, Status: "ok"}
+			}
+			if reason, ok := conformance.SkipReason(err); ok {
+				return FuncResult{Name: name, Status: "skipped", Error: reason}
+			}
+			return errorResult(name, err)
+		}))
+	}
+	return out
+}
+
+func testLegacy(ctx context.Context, p *plugin.Plugin, pr *PluginResult, cfg RunConfig, scenarios []Scenario) {
 	// Legacy symbol path without reflection: type-assert known signatures
 	wrap := func(name string, fn func() FuncResult) FuncResult {
-		start := time.Now()
-		done := make(chan FuncResult, 1)
-		go func() { done <- fn() }()
-		select {
-		case r := <-done:
-			r.Elapsed = time.Since(start)
-			return r
-		case <-ctx.Done():
-			return FuncResult{Name: name, Status: "timeout", Elapsed: time.Since(start)}
-		case <-time.After(cfg.Timeout):
-			return FuncResult{Name: name, Status: "timeout", Elapsed: time.Since(start)}
-		}
+		return invoke(ctx, cfg.Timeout, name, func(context.Context) FuncResult { return fn() })
 	}
 
 	look := func(sym string) (any, bool) { s, err := p.Lookup(sym); return s, err == nil }
 
-	// Meta
+	// Meta; kept for credential validation once the call has succeeded
+	var meta *models.MetaData
 	if sym, ok := look("Meta"); ok {
 		switch fn := sym.(type) {
 		case func() models.MetaData:
-			pr.Funcs = append(pr.Funcs, wrap("Meta", func() FuncResult {
-				meta := fn()
-				if v := meta.ContractVersion; v != "" && !typing.IsCompatible(v) {
+			var got *models.MetaData
+			r := wrap("Meta", func() FuncResult {
+				m := fn()
+				if v := m.ContractVersion; v != "" && !typing.IsCompatible(v) {
 					return FuncResult{Name: "Contract", Status: "error", Error: typing.IncompatibilityMessage(v)}
 				}
+				got = &m
 				return FuncResult{Name: "Meta", Status: "ok"}
-			}))
+			})
+			pr.Funcs = append(pr.Funcs, r)
+			// got is only safe to read once the call has completed.
+			if r.Status == "ok" {
+				meta = got
+			}
+			if err := host.ValidateAuthType(meta); err != nil {
+				pr.Funcs = append(pr.Funcs, FuncResult{Name: "AuthType", Status: "error", Error: err.Error()})
+			}
 		default:
 			pr.Funcs = append(pr.Funcs, FuncResult{Name: "Meta", Status: "error", Error: "invalid Meta signature"})
 		}
@@ -320,51 +451,245 @@ func testOne(ctx context.Context, file string, cfg RunConfig) PluginResult {
 		pr.Funcs = append(pr.Funcs, FuncResult{Name: "RunTests", Status: "skipped"})
 	}
 
-	// Contacts
-	if sym, ok := look("Contacts"); ok {
+	// Optional Auth (legacy AuthFunc signature)
+	var authFn models.AuthFunc
+	if sym, ok := look("Auth"); ok {
 		switch fn := sym.(type) {
-		case func(models.AuthCredentials, models.ContactQueryParams) (*models.Contacts, error):
-			pr.Funcs = append(pr.Funcs, wrap("Contacts", func() FuncResult {
-				if _, err := fn(cfg.Auth, cfg.ContactParams); err != nil {
-					return FuncResult{Name: "Contacts", Status: "error", Error: err.Error()}
-				}
-				return FuncResult{Name: "Contacts", Status: "ok"}
-			}))
+		case func(models.InitialAuthCredentials, models.AuthParams) (*models.AuthCredentials, error):
+			authFn = fn
 		default:
-			pr.Funcs = append(pr.Funcs, FuncResult{Name: "Contacts", Status: "error", Error: "invalid Contacts signature"})
+			pr.Funcs = append(pr.Funcs, FuncResult{Name: "Auth", Status: "error", Error: "invalid Auth signature"})
+			return
 		}
 	} else {
-		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Contacts", Status: "missing"})
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Auth", Status: "skipped"})
 	}
 
-	// Ledger (core)
-	if sym, ok := look("Ledger"); ok {
-		switch fn := sym.(type) {
-		case func(models.AuthCredentials, models.LedgerQueryParams) (*models.Ledger, error):
-			pr.Funcs = append(pr.Funcs, wrap("Ledger", func() FuncResult {
-				if _, err := fn(cfg.Auth, cfg.LedgerParams); err != nil {
-					return FuncResult{Name: "Ledger", Status: "error", Error: err.Error()}
-				}
-				return FuncResult{Name: "Ledger", Status: "ok"}
-			}))
-		default:
-			pr.Funcs = append(pr.Funcs, FuncResult{Name: "Ledger", Status: "error", Error: "invalid Ledger signature"})
+	for _, sc := range scenarios {
+		if authFn != nil {
+			var ok bool
+			if sc, ok = runAuth(ctx, cfg, pr, sc, func(context.Context) (*models.AuthCredentials, error) {
+				return host.ValidatedAuth(meta, sc.AuthParams, func(params models.AuthParams) (*models.AuthCredentials, error) {
+					return authFn(sc.Auth, params)
+				})
+			}); !ok {
+				continue
+			}
 		}
-	} else {
-		pr.Funcs = append(pr.Funcs, FuncResult{Name: "Ledger", Status: "missing"})
+		// Contacts
+		if sym, ok := look("Contacts"); ok {
+			switch fn := sym.(type) {
+			case func(models.AuthCredentials, models.ContactQueryParams) (*models.Contacts, error):
+				pr.Funcs = append(pr.Funcs, inScenario(sc, wrap("Contacts", func() FuncResult {
+					if _, err := fn(sc.Auth, sc.ContactParams); err != nil {
+						return FuncResult{Name: "Contacts", Status: "error", Error: err.Error()}
+					}
+					return FuncResult{Name: "Contacts", Status: "ok"}
+				})))
+			default:
+				pr.Funcs = append(pr.Funcs, inScenario(sc, FuncResult{Name: "Contacts", Status: "error", Error: "invalid Contacts signature"}))
+			}
+		} else {
+			pr.Funcs = append(pr.Funcs, inScenario(sc, FuncResult{Name: "Contacts", Status: "missing"}))
+		}
+
+		// Ledger (core)
+		if sym, ok := look("Ledger"); ok {
+			switch fn := sym.(type) {
+			case func(models.AuthCredentials, models.LedgerQueryParams) (*models.Ledger, error):
+				pr.Funcs = append(pr.Funcs, inScenario(sc, wrap("Ledger", func() FuncResult {
+					if err := sc.LedgerParams.Validate(); err != nil {
+						return FuncResult{Name: "Ledger", Status: "error", Error: "invalid ledger params: " + err.Error()}
+					}
+					l, err := fn(sc.Auth, sc.LedgerParams)
+					if err == nil {
+						err = checkLedger(l, sc.LedgerParams)
+					}
+					if err != nil {
+						return FuncResult{Name: "Ledger", Status: "error", Error: err.Error()}
+					}
+					return FuncResult{Name: "Ledger", Status: "ok"}
+				})))
+			default:
+				pr.Funcs = append(pr.Funcs, inScenario(sc, FuncResult{Name: "Ledger", Status: "error", Error: "invalid Ledger signature"}))
+			}
+		} else {
+			pr.Funcs = append(pr.Funcs, inScenario(sc, FuncResult{Name: "Ledger", Status: "missing"}))
+		}
+	}
+
+	if cfg.Mode == ModeConformance {
+		pr.Funcs = append(pr.Funcs, FuncResult{Name: "conformance", Status: "skipped", Error: "requires a typed Plugin symbol"})
 	}
 
 	// If mode is "source", run `go test` in the plugin's directory
 	if cfg.Mode == ModeSource || cfg.Mode == ModeAll {
-		st := runSourceTests(ctx, cfg.BaseDir, base)
+		st := runSourceTests(ctx, cfg.BaseDir, pr.Name)
 		pr.SourceTest = &st
 	}
-
-	return pr
 }
 
 // reflect-based invocation removed in favor of typed interfaces and type assertions.
 
+// invoke runs fn bounded by the run timeout and cancellation. fn receives a
+// context carrying that deadline, which context-aware plugins use to stop
+// their work once the call is abandoned. Panics are recovered and reported as
+// a "panic" result so that one misbehaving plugin cannot abort the remaining
+// calls or plugins.
+func invoke(ctx context.Context, timeout time.Duration, name string, fn func(ctx context.Context) FuncResult) FuncResult {
+	start := time.Now()
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan FuncResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- panicResult(name, r, debug.Stack())
+			}
+		}()
+		done <- fn(callCtx)
+	}()
+	select {
+	case r := <-done:
+		r.Elapsed = time.Since(start)
+		return r
+	case <-callCtx.Done():
+		return FuncResult{Name: name, Status: "timeout", Elapsed: time.Since(start)}
+	}
+}
+
+// withOAuth2Token reports loading (and refreshing) the plugin's stored token
+// as "OAuth2" and returns the scenarios with the token merged under their
+// Auth. A missing token is reported as skipped.
+func withOAuth2Token(ctx context.Context, cfg RunConfig, pr *PluginResult, meta *models.MetaData, scenarios []Scenario) []Scenario {
+	var tok *host.OAuth2Token
+	r := invoke(ctx, cfg.Timeout, "OAuth2", func(ctx context.Context) FuncResult {
+		var err error
+		if tok, err = host.FreshToken(ctx, cfg.Tokens, pr.Name, meta.OAuth2); err != nil {
+			if errors.Is(err, host.ErrNoToken) {
+				return FuncResult{Name: "OAuth2", Status: "skipped", Error: err.Error()}
+			}
+			return errorResult("OAuth2", err)
+		}
+		return FuncResult{Name: "OAuth2", Status: "ok"}
+	})
+	pr.Funcs = append(pr.Funcs, r)
+	if r.Status != "ok" {
+		return scenarios
+	}
+	out := make([]Scenario, len(scenarios))
+	for i, sc := range scenarios {
+		creds := tok.Credentials()
+		maps.Copy(creds, sc.Auth)
+		sc.Auth = creds
+		out[i] = sc
+	}
+	return out
+}
+
+// withStoredCredentials reports loading the plugin's cfg.Profile credentials
+// as "Credentials" and returns the scenarios with them merged under their
+// Auth. A plugin without that profile is reported as skipped. The store is
+// read outside invoke because it may prompt for the passphrase.
+func withStoredCredentials(cfg RunConfig, pr *PluginResult, scenarios []Scenario) []Scenario {
+	start := time.Now()
+	stored, err := cfg.Credentials.Get(pr.Name, cfg.Profile)
+	r := FuncResult{Name: "Credentials", Status: "ok", Elapsed: time.Since(start)}
+	if err != nil {
+		r.Status, r.Error = "error", err.Error()
+		if errors.Is(err, credstore.ErrNotFound) {
+			r.Status = "skipped"
+		}
+	}
+	pr.Funcs = append(pr.Funcs, r)
+	if r.Status != "ok" {
+		return scenarios
+	}
+	out := make([]Scenario, len(scenarios))
+	for i, sc := range scenarios {
+		creds := maps.Clone(stored)
+		maps.Copy(creds, sc.Auth)
+		sc.Auth = creds
+		out[i] = sc
+	}
+	return out
+}
+
+// runAuth reports the scenario's Auth call and returns the scenario with the
+// returned credentials merged over its Auth. When Auth does not succeed the
+// scenario's Contacts and Ledger calls are reported as skipped and ok is false.
+func runAuth(ctx context.Context, cfg RunConfig, pr *PluginResult, sc Scenario, auth func(context.Context) (*models.AuthCredentials, error)) (Scenario, bool) {
+	var creds *models.AuthCredentials
+	r := invoke(ctx, cfg.Timeout, "Auth", func(ctx context.Context) FuncResult {
+		var err error
+		if creds, err = auth(ctx); err != nil {
+			return errorResult("Auth", err)
+		}
+		if creds == nil {
+			return FuncResult{Name: "Auth", Status: "error", Error: "Auth returned no credentials"}
+		}
+		return FuncResult{Name: "Auth", Status: "ok"}
+	})
+	pr.Funcs = append(pr.Funcs, inScenario(sc, r))
+	if r.Status != "ok" {
+		for _, name := range []string{"Contacts", "Ledger"} {
+			pr.Funcs = append(pr.Funcs, inScenario(sc, FuncResult{Name: name, Status: "skipped", Error: "Auth failed"}))
+		}
+		return sc, false
+	}
+	merged := maps.Clone(sc.Auth)
+	if merged == nil {
+		merged = models.AuthCredentials{}
+	}
+	maps.Copy(merged, *creds)
+	sc.Auth = merged
+	return sc, true
+}
+
+// checkLedger validates a Ledger result: its amounts, and its entry dates
+// against the range and order params asked for.
+func checkLedger(l *models.Ledger, params models.LedgerQueryParams) error {
+	if err := host.ValidateLedger(l); err != nil {
+		return err
+	}
+	return host.ValidateLedgerDates(l, params)
+}
+
+// inScenario tags r with the scenario it ran under.
+func inScenario(sc Scenario, r FuncResult) FuncResult {
+	r.Scenario = sc.Name
+	return r
+}
+
+func panicResult(name string, value any, stack []byte) FuncResult {
+	v := fmt.Sprint(value)
+	return FuncResult{Name: name, Status: "panic", Error: v, Panic: v, Stack: string(stack)}
+}
+
+// errorResult converts a plugin error into a result, recognising panics that
+// were recovered inside an out-of-process plugin.
+func errorResult(name string, err error) FuncResult {
+	var pe *pluginrpc.PanicError
+	if errors.As(err, &pe) {
+		return FuncResult{Name: name, Status: "panic", Error: pe.Value, Panic: pe.Value, Stack: pe.Stack}
+	}
+	return FuncResult{Name: name, Status: "error", Error: err.Error()}
+}
+
+// globPlugins lists compiled plugins of every transport inside dir (non-recursive).
+func globPlugins(dir string) []string {
+	var out []string
+	for _, suffix := range []string{host.NativeSuffix, host.RPCSuffix} {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*"+suffix))
+		for _, m := range matches {
+			if st, err := os.Stat(m); err == nil && !st.IsDir() {
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
 func runSourceTests(ctx context.Context, baseDir, name string) FuncResult {
 	// pkgs/<name>
 	src := filepath.Join(baseDir, "plugins", "pkgs", name)
@@ -395,13 +720,24 @@ func PrintHuman(rr RunResult) {
 		return
 	}
 	for _, p := range rr.Plugins {
-		logger.Info("Plugin: %s (%s)", p.Name, p.File)
+		if p.Transport != "" {
+			logger.Info("Plugin: %s (%s, %s)", p.Name, p.File, p.Transport)
+		} else {
+			logger.Info("Plugin: %s (%s)", p.Name, p.File)
+		}
 		for _, f := range p.Funcs {
 			msg := f.Status
 			if f.Error != "" {
 				msg += ": " + f.Error
 			}
-			logger.Info("  - %s: %s (%s)", f.Name, msg, f.Elapsed.String())
+			name := f.Name
+			if f.Scenario != "" {
+				name = "[" + f.Scenario + "] " + name
+			}
+			logger.Info("  - %s: %s (%s)", name, msg, f.Elapsed.String())
+			if f.Stack != "" && logger.IsDebugMode() {
+				logger.Debug("%s", f.Stack)
+			}
 		}
 		if p.SourceTest != nil {
 			st := p.SourceTest
@@ -412,6 +748,12 @@ func PrintHuman(rr RunResult) {
 			logger.Info("  - %s: %s", st.Name, msg)
 		}
 	}
+	if len(rr.Panics) > 0 {
+		logger.Warn("Panics: %d", len(rr.Panics))
+		for _, p := range rr.Panics {
+			logger.Warn("  - %s.%s: %s", p.Plugin, p.Func, p.Value)
+		}
+	}
 	if rr.Failures > 0 {
 		logger.Warn("Failures: %d", rr.Failures)
 	} else {
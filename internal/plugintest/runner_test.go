@@ -0,0 +1,65 @@
+package plugintest
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInvoke(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should recover panics", func(t *testing.T) {
+		r := invoke(ctx, time.Second, "Contacts", func(context.Context) FuncResult {
+			panic("boom")
+		})
+		if r.Status != "panic" {
+			t.Fatalf("Expected status panic, got %s", r.Status)
+		}
+		if r.Panic != "boom" {
+			t.Errorf("Expected panic value 'boom', got '%s'", r.Panic)
+		}
+		if r.Stack == "" {
+			t.Error("Expected a stack trace")
+		}
+	})
+
+	t.Run("should time out slow calls", func(t *testing.T) {
+		r := invoke(ctx, 10*time.Millisecond, "Ledger", func(context.Context) FuncResult {
+			time.Sleep(time.Second)
+			return FuncResult{Name: "Ledger", Status: "ok"}
+		})
+		if r.Status != "timeout" {
+			t.Errorf("Expected status timeout, got %s", r.Status)
+		}
+	})
+
+	t.Run("should cancel the call context on timeout", func(t *testing.T) {
+		cancelled := make(chan struct{})
+		r := invoke(ctx, 10*time.Millisecond, "Contacts", func(ctx context.Context) FuncResult {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("Expected call context to carry a deadline")
+			}
+			<-ctx.Done()
+			close(cancelled)
+			return FuncResult{Name: "Contacts", Status: "ok"}
+		})
+		if r.Status != "timeout" {
+			t.Errorf("Expected status timeout, got %s", r.Status)
+		}
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Error("Expected plugin call to observe cancellation")
+		}
+	})
+
+	t.Run("should pass through results", func(t *testing.T) {
+		r := invoke(ctx, time.Second, "Health", func(context.Context) FuncResult {
+			return FuncResult{Name: "Health", Status: "ok"}
+		})
+		if r.Status != "ok" || r.Name != "Health" {
+			t.Errorf("Unexpected result: %+v", r)
+		}
+	})
+}
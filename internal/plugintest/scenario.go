@@ -0,0 +1,147 @@
+package plugintest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/internal/registry"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioFileName is looked up in a plugin's source directory.
+const ScenarioFileName = "uagtest.yaml"
+
+// Scenario is one named set of inputs for the Auth, Contacts and Ledger calls.
+type Scenario struct {
+	Name          string                    `json:"name"`
+	Auth          models.AuthCredentials    `json:"auth,omitempty"`
+	AuthParams    models.AuthParams         `json:"auth_params"`
+	ContactParams models.ContactQueryParams `json:"contact_params"`
+	LedgerParams  models.LedgerQueryParams  `json:"ledger_params"`
+}
+
+type scenarioFile struct {
+	Scenarios []struct {
+		Name          string          `json:"name"`
+		Auth          json.RawMessage `json:"auth"`
+		AuthParams    json.RawMessage `json:"auth_params"`
+		ContactParams json.RawMessage `json:"contact_params"`
+		LedgerParams  json.RawMessage `json:"ledger_params"`
+	} `json:"scenarios"`
+}
+
+// LoadScenarios reads a uagtest.yaml (or .json) file. Each scenario starts
+// from base and overrides only the fields it sets, so the command line flags
+// act as defaults for every scenario.
+//
+//	scenarios:
+//	  - name: search
+//	    auth: {token: abc}
+//	    auth_params: {api_key: secret, data: {organization_id: acme}}
+//	    contact_params: {search: smith, limit: 10}
+//	    ledger_params: {customer_id: "1", doc_types: [invoice]}
+func LoadScenarios(path string, base Scenario) ([]Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// YAML is converted to JSON so the models' json tags name the fields.
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	var f scenarioFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(f.Scenarios) == 0 {
+		return nil, fmt.Errorf("%s declares no scenarios", path)
+	}
+
+	seen := map[string]bool{}
+	out := make([]Scenario, 0, len(f.Scenarios))
+	for i, raw := range f.Scenarios {
+		if raw.Name == "" {
+			return nil, fmt.Errorf("%s: scenario #%d has no name", path, i+1)
+		}
+		if seen[raw.Name] {
+			return nil, fmt.Errorf("%s: scenario %q is declared twice", path, raw.Name)
+		}
+		seen[raw.Name] = true
+		s := Scenario{Name: raw.Name}
+		if err := overlay(base.Auth, raw.Auth, &s.Auth); err != nil {
+			return nil, fmt.Errorf("%s: scenario %q auth: %w", path, raw.Name, err)
+		}
+		if err := overlay(base.AuthParams, raw.AuthParams, &s.AuthParams); err != nil {
+			return nil, fmt.Errorf("%s: scenario %q auth_params: %w", path, raw.Name, err)
+		}
+		if err := overlay(base.ContactParams, raw.ContactParams, &s.ContactParams); err != nil {
+			return nil, fmt.Errorf("%s: scenario %q contact_params: %w", path, raw.Name, err)
+		}
+		if err := overlay(base.LedgerParams, raw.LedgerParams, &s.LedgerParams); err != nil {
+			return nil, fmt.Errorf("%s: scenario %q ledger_params: %w", path, raw.Name, err)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// overlay decodes base and then patch into out. Going through JSON copies
+// base, so scenarios never share its maps or slices.
+func overlay(base any, patch json.RawMessage, out any) error {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return err
+	}
+	if len(patch) == 0 || string(patch) == "null" {
+		return nil
+	}
+	return json.Unmarshal(patch, out)
+}
+
+// scenariosFor returns the scenarios to run for the named plugin: those in
+// cfg.ScenarioFile, else those in the plugin's source directory, else a
+// single unnamed scenario built from the flags.
+func scenariosFor(cfg RunConfig, name string) ([]Scenario, error) {
+	base := Scenario{Auth: cfg.Auth, AuthParams: cfg.AuthParams, ContactParams: cfg.ContactParams, LedgerParams: cfg.LedgerParams}
+	if cfg.ScenarioFile != "" {
+		return LoadScenarios(cfg.ScenarioFile, base)
+	}
+	for _, dir := range sourceDirs(cfg.BaseDir, name) {
+		path := filepath.Join(dir, ScenarioFileName)
+		if _, err := os.Stat(path); err == nil {
+			return LoadScenarios(path, base)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return []Scenario{base}, nil
+}
+
+// sourceDirs lists where the source of an installed plugin may live: the
+// directory it was installed from, then its clone under pkgs.
+func sourceDirs(baseDir, name string) []string {
+	var dirs []string
+	if reg, err := registry.Load(); err == nil {
+		if e, ok := reg.Get(name); ok && e.SourceType == registry.SourceDir {
+			dirs = append(dirs, e.Source)
+		}
+	}
+	if baseDir != "" {
+		dirs = append(dirs, filepath.Join(baseDir, "pkgs", name))
+	}
+	return dirs
+}
@@ -0,0 +1,205 @@
+package plugintest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func writeScenarios(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadScenarios(t *testing.T) {
+	base := Scenario{
+		Auth:          models.AuthCredentials{"token": "flag"},
+		ContactParams: models.ContactQueryParams{CommonParams: models.CommonParams{Limit: 5, Extras: map[string]string{"region": "eu"}}},
+	}
+
+	t.Run("should overlay each scenario on the flag values", func(t *testing.T) {
+		path := writeScenarios(t, ScenarioFileName, `
+scenarios:
+  - name: default
+  - name: search
+    auth: {tenant: acme}
+    contact_params:
+      search: smith
+      search_ids: ["1", "2"]
+      extras: {region: us}
+    ledger_params:
+      customer_id: "7"
+      doc_types: [invoice, payment]
+`)
+		got, err := LoadScenarios(path, base)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("Expected 2 scenarios, got %d", len(got))
+		}
+		if got[0].Name != "default" || got[0].ContactParams.Limit != 5 || got[0].Auth["token"] != "flag" {
+			t.Errorf("Expected default scenario to keep flag values, got %+v", got[0])
+		}
+		s := got[1]
+		if s.Auth["token"] != "flag" || s.Auth["tenant"] != "acme" {
+			t.Errorf("Expected merged auth, got %v", s.Auth)
+		}
+		if s.ContactParams.Search != "smith" || len(s.ContactParams.SearchIDs) != 2 || s.ContactParams.Limit != 5 {
+			t.Errorf("Expected search params with limit 5, got %+v", s.ContactParams)
+		}
+		if s.ContactParams.Extras["region"] != "us" || base.ContactParams.Extras["region"] != "eu" {
+			t.Errorf("Expected scenario extras to override without touching base, got %v and %v", s.ContactParams.Extras, base.ContactParams.Extras)
+		}
+		if s.LedgerParams.CustomerID != "7" || len(s.LedgerParams.DocTypes) != 2 || s.LedgerParams.DocTypes[1] != models.DocTypePayment {
+			t.Errorf("Expected ledger params, got %+v", s.LedgerParams)
+		}
+	})
+
+	t.Run("should read JSON files", func(t *testing.T) {
+		path := writeScenarios(t, "uagtest.json", `{"scenarios":[{"name":"j","contact_params":{"limit":1}}]}`)
+		got, err := LoadScenarios(path, Scenario{})
+		if err != nil || len(got) != 1 || got[0].ContactParams.Limit != 1 {
+			t.Errorf("Expected one scenario with limit 1, got %+v, %v", got, err)
+		}
+	})
+
+	t.Run("should reject invalid files", func(t *testing.T) {
+		for _, content := range []string{
+			"scenarios: []",
+			"scenarios:\n  - contact_params: {limit: 1}",
+			"scenarios:\n  - name: a\n  - name: a",
+			"scenarios:\n  - name: a\n    contact_params: {limit: ten}",
+		} {
+			if _, err := LoadScenarios(writeScenarios(t, ScenarioFileName, content), base); err == nil {
+				t.Errorf("Expected error for %q", content)
+			}
+		}
+	})
+}
+
+type recordingPlugin struct {
+	searches []string
+	tokens   []string
+}
+
+func (p *recordingPlugin) Meta() *models.MetaData { return &models.MetaData{ID: "rec"} }
+func (p *recordingPlugin) Health() string         { return "ok" }
+
+func (p *recordingPlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	p.searches = append(p.searches, params.Search)
+	p.tokens = append(p.tokens, auth["token"])
+	return &models.Contacts{}, nil
+}
+
+func (p *recordingPlugin) Ledger(models.AuthCredentials, models.LedgerQueryParams) (*models.Ledger, error) {
+	return &models.Ledger{}, nil
+}
+
+func TestTypedScenarios(t *testing.T) {
+	t.Run("should run Contacts and Ledger once per scenario", func(t *testing.T) {
+		fake := &recordingPlugin{}
+		scenarios := []Scenario{
+			{Name: "a", Auth: models.AuthCredentials{"token": "x"}, ContactParams: models.ContactQueryParams{Search: "alice"}},
+			{Name: "b", Auth: models.AuthCredentials{"token": "y"}, ContactParams: models.ContactQueryParams{Search: "bob"}},
+		}
+		var pr PluginResult
+		testTyped(context.Background(), &host.Plugin{Plugin: fake}, &pr, RunConfig{Timeout: time.Second}, scenarios)
+
+		if strings.Join(fake.searches, ",") != "alice,bob" || strings.Join(fake.tokens, ",") != "x,y" {
+			t.Errorf("Expected calls with each scenario, got searches %v tokens %v", fake.searches, fake.tokens)
+		}
+		var names []string
+		for _, f := range pr.Funcs {
+			if f.Scenario != "" {
+				names = append(names, f.Scenario+"/"+f.Name)
+			}
+		}
+		if got := strings.Join(names, ","); got != "a/Contacts,a/Ledger,b/Contacts,b/Ledger" {
+			t.Errorf("Expected per-scenario results, got %s", got)
+		}
+	})
+}
+
+func TestRunAuth(t *testing.T) {
+	ctx := context.Background()
+	cfg := RunConfig{Timeout: time.Second}
+	sc := Scenario{Name: "s", Auth: models.AuthCredentials{"tenant": "acme", "token": "flag"}, AuthParams: models.AuthParams{APIKey: "k"}}
+
+	t.Run("should merge returned credentials into the scenario", func(t *testing.T) {
+		var pr PluginResult
+		var gotKey string
+		out, ok := runAuth(ctx, cfg, &pr, sc, func(context.Context) (*models.AuthCredentials, error) {
+			gotKey = sc.AuthParams.APIKey
+			return &models.AuthCredentials{"token": "issued"}, nil
+		})
+		if !ok || gotKey != "k" {
+			t.Fatalf("Expected successful Auth with api key k, got ok=%v key=%q", ok, gotKey)
+		}
+		if out.Auth["token"] != "issued" || out.Auth["tenant"] != "acme" {
+			t.Errorf("Expected issued token over flag credentials, got %v", out.Auth)
+		}
+		if sc.Auth["token"] != "flag" {
+			t.Errorf("Expected the original scenario to be unchanged, got %v", sc.Auth)
+		}
+		if len(pr.Funcs) != 1 || pr.Funcs[0].Name != "Auth" || pr.Funcs[0].Status != "ok" || pr.Funcs[0].Scenario != "s" {
+			t.Errorf("Expected one ok Auth result, got %+v", pr.Funcs)
+		}
+	})
+
+	t.Run("should skip data calls when Auth fails", func(t *testing.T) {
+		var pr PluginResult
+		_, ok := runAuth(ctx, cfg, &pr, sc, func(context.Context) (*models.AuthCredentials, error) {
+			return nil, errors.New("invalid API key")
+		})
+		if ok {
+			t.Fatal("Expected Auth to fail")
+		}
+		var got []string
+		for _, f := range pr.Funcs {
+			got = append(got, f.Name+":"+f.Status)
+		}
+		if strings.Join(got, ",") != "Auth:error,Contacts:skipped,Ledger:skipped" {
+			t.Errorf("Expected Auth error and skipped calls, got %v", got)
+		}
+	})
+
+	t.Run("should reject nil credentials", func(t *testing.T) {
+		var pr PluginResult
+		if _, ok := runAuth(ctx, cfg, &pr, sc, func(context.Context) (*models.AuthCredentials, error) { return nil, nil }); ok {
+			t.Error("Expected nil credentials to fail Auth")
+		}
+	})
+}
+
+// metaErrorPlugin fails its remote Meta call like a panicking RPC plugin.
+type metaErrorPlugin struct{ recordingPlugin }
+
+func (*metaErrorPlugin) MetaContext(context.Context) (*models.MetaData, error) {
+	return nil, errors.New("plugin panic: meta exploded")
+}
+
+func TestTypedMetaError(t *testing.T) {
+	t.Run("should fail Meta when the remote call fails", func(t *testing.T) {
+		var pr PluginResult
+		testTyped(context.Background(), &host.Plugin{Plugin: &metaErrorPlugin{}}, &pr, RunConfig{Timeout: time.Second}, nil)
+
+		if len(pr.Funcs) != 1 || pr.Funcs[0].Name != "Meta" || pr.Funcs[0].Status != "error" {
+			t.Fatalf("Expected a single failed Meta result, got %+v", pr.Funcs)
+		}
+		if !strings.Contains(pr.Funcs[0].Error, "meta exploded") {
+			t.Errorf("Expected the Meta error to be reported, got '%s'", pr.Funcs[0].Error)
+		}
+	})
+}
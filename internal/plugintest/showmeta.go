@@ -1,33 +1,19 @@
 package plugintest
 
 import (
-	"errors"
-	"fmt"
-	"plugin"
+	"context"
 
+	"github.com/nikhiljohn10/uagplugin/host"
 	"github.com/nikhiljohn10/uagplugin/models"
-	"github.com/nikhiljohn10/uagplugin/typing"
 )
 
+// GetPluginMetadata loads a compiled plugin of any transport and returns its metadata.
 func GetPluginMetadata(filePath string) (*models.MetaData, error) {
-	p, err := plugin.Open(filePath)
+	p, err := host.Open(context.Background(), filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open plugin file: %w", err)
+		return nil, err
 	}
+	defer p.Close()
 
-	sym, err := p.Lookup("Plugin")
-	if err != nil {
-		return nil, fmt.Errorf("failed to lookup 'Plugin' symbol: %w", err)
-	}
-
-	pl, ok := sym.(typing.Plugin)
-	if !ok {
-		if plPtr, ok := sym.(*typing.Plugin); ok && plPtr != nil {
-			pl = *plPtr
-		} else {
-			return nil, errors.New("unexpected type for symbol 'Plugin', expected typing.Plugin")
-		}
-	}
-
-	return pl.Meta(), nil
+	return p.MetaContext(context.Background())
 }
@@ -0,0 +1,145 @@
+// Package registry records installed plugins in ~/.uag/plugins/registry.json
+// so the CLI knows where each plugin came from and what was built.
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/utils"
+)
+
+const fileName = "registry.json"
+
+// Source types recorded for an installed plugin.
+const (
+	SourceRepo = "repo"
+	SourceDir  = "dir"
+)
+
+// Entry describes one installed plugin.
+type Entry struct {
+	Name       string `json:"name"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	// Ref is the requested ref (range, branch or commit) when not "latest".
+	Ref             string         `json:"ref,omitempty"`
+	Tag             string         `json:"tag,omitempty"`
+	Commit          string         `json:"commit,omitempty"`
+	Runtime         host.Transport `json:"runtime"`
+	Artifact        string         `json:"artifact"`
+	Checksum        string         `json:"checksum"`
+	BuiltAt         time.Time      `json:"built_at"`
+	GoVersion       string         `json:"go_version"`
+	ContractVersion string         `json:"contract_version,omitempty"`
+	PluginVersion   string         `json:"plugin_version,omitempty"`
+}
+
+// Registry is the on-disk set of installed plugins keyed by name.
+type Registry struct {
+	Plugins map[string]Entry `json:"plugins"`
+
+	path string
+}
+
+// Path returns the default registry location.
+func Path() (string, error) {
+	baseDir, err := utils.GetBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(baseDir, fileName), nil
+}
+
+// Load reads the default registry; a missing file yields an empty registry.
+func Load() (*Registry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the registry at path; a missing file yields an empty registry.
+func LoadFile(path string) (*Registry, error) {
+	r := &Registry{Plugins: map[string]Entry{}, path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read plugin registry: %w", err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin registry %s: %w", path, err)
+	}
+	if r.Plugins == nil {
+		r.Plugins = map[string]Entry{}
+	}
+	return r, nil
+}
+
+// Save writes the registry atomically.
+func (r *Registry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+// Get returns the entry for name.
+func (r *Registry) Get(name string) (Entry, bool) {
+	e, ok := r.Plugins[name]
+	return e, ok
+}
+
+// Put adds or replaces the entry for e.Name.
+func (r *Registry) Put(e Entry) {
+	r.Plugins[e.Name] = e
+}
+
+// Remove deletes the entry for name.
+func (r *Registry) Remove(name string) {
+	delete(r.Plugins, name)
+}
+
+// List returns all entries sorted by name.
+func (r *Registry) List() []Entry {
+	out := make([]Entry, 0, len(r.Plugins))
+	for _, e := range r.Plugins {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Checksum returns the hex SHA-256 of the file at path.
+func Checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
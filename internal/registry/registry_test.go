@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.json")
+
+	t.Run("should load an empty registry when the file is missing", func(t *testing.T) {
+		r, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(r.List()) != 0 {
+			t.Errorf("Expected no entries, got %d", len(r.List()))
+		}
+	})
+
+	t.Run("should round trip entries through Save", func(t *testing.T) {
+		r, _ := LoadFile(path)
+		built := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		r.Put(Entry{Name: "zeta", SourceType: SourceDir, Source: "/src/zeta", Runtime: host.TransportRPC, BuiltAt: built})
+		r.Put(Entry{Name: "alpha", SourceType: SourceRepo, Source: "https://github.com/o/alpha", Tag: "v1.2.3", Runtime: host.TransportNative, BuiltAt: built})
+		if err := r.Save(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		loaded, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		list := loaded.List()
+		if len(list) != 2 || list[0].Name != "alpha" || list[1].Name != "zeta" {
+			t.Fatalf("Expected [alpha zeta], got %+v", list)
+		}
+		if list[0].Tag != "v1.2.3" || list[1].Runtime != host.TransportRPC || !list[0].BuiltAt.Equal(built) {
+			t.Errorf("Expected fields to survive round trip, got %+v", list)
+		}
+	})
+
+	t.Run("should remove entries", func(t *testing.T) {
+		r, _ := LoadFile(path)
+		r.Remove("alpha")
+		if _, ok := r.Get("alpha"); ok {
+			t.Errorf("Expected alpha to be removed")
+		}
+		if _, ok := r.Get("zeta"); !ok {
+			t.Errorf("Expected zeta to remain")
+		}
+	})
+
+	t.Run("should reject a corrupt file", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.json")
+		if err := os.WriteFile(bad, []byte("{"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(bad); err == nil {
+			t.Errorf("Expected error for corrupt registry")
+		}
+	})
+}
+
+func TestChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Checksum(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
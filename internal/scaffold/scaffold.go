@@ -0,0 +1,148 @@
+// Package scaffold generates the source tree of a new plugin for
+// `uagplugin init`.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Templates lists the available plugin templates.
+var Templates = []string{"file", "api", "auth"}
+
+// Options configures Generate.
+type Options struct {
+	// Name is the plugin ID, e.g. "acme-crm".
+	Name string
+	// Template is one of Templates.
+	Template string
+	// Auth adds an Authenticator stub; the auth template always has one.
+	Auth bool
+}
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ValidateName reports whether name can be used as a plugin ID.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid plugin name %q: use lowercase letters, digits, '-' and '_', starting with a letter", name)
+	}
+	return nil
+}
+
+type data struct {
+	Name     string
+	Title    string
+	Type     string
+	Template string
+	Auth     bool
+}
+
+// Generate writes the plugin sources (everything but go.mod) into dir, which
+// must be empty or missing, and returns the files written relative to dir.
+func Generate(dir string, opts Options) ([]string, error) {
+	if err := ValidateName(opts.Name); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(Templates, opts.Template) {
+		return nil, fmt.Errorf("unknown template %q (choose %s)", opts.Template, strings.Join(Templates, ", "))
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	d := data{
+		Name:     opts.Name,
+		Title:    title(opts.Name),
+		Type:     strings.ReplaceAll(title(opts.Name), " ", "") + "Plugin",
+		Template: opts.Template,
+		Auth:     opts.Auth || opts.Template == "auth",
+	}
+	// The auth template is the api template with an Authenticator.
+	source := opts.Template
+	if source == "auth" {
+		source = "api"
+	}
+	files := map[string]string{
+		"plugin.go":      "templates/plugin.go.tmpl",
+		"plugin_test.go": "templates/plugin_test.go.tmpl",
+	}
+	if d.Auth {
+		files["auth.go"] = "templates/auth.go.tmpl"
+	}
+	err := fs.WalkDir(templates, "templates/"+source, func(path string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		files[strings.TrimSuffix(filepath.Base(path), ".tmpl")] = path
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for name, path := range files {
+		out, err := render(path, d)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(name, ".go") {
+			if out, err = format.Source(out); err != nil {
+				return nil, fmt.Errorf("template %s: %w", path, err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), out, 0644); err != nil {
+			return nil, err
+		}
+		written = append(written, name)
+	}
+	slices.Sort(written)
+	return written, nil
+}
+
+func render(path string, d data) ([]byte, error) {
+	src, err := templates.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".tmpl") {
+		return src, nil
+	}
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// title turns "acme-crm" into "Acme Crm".
+func title(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
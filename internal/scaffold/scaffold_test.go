@@ -0,0 +1,85 @@
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		template string
+		auth     bool
+		files    []string
+	}{
+		{"file", false, []string{"contacts.csv", "ledger.csv", "plugin.go", "plugin_test.go", "source.go"}},
+		{"file", true, []string{"auth.go", "contacts.csv", "ledger.csv", "plugin.go", "plugin_test.go", "source.go"}},
+		{"api", false, []string{"plugin.go", "plugin_test.go", "source.go"}},
+		{"auth", false, []string{"auth.go", "plugin.go", "plugin_test.go", "source.go"}},
+	}
+	for _, tc := range cases {
+		name := tc.template
+		if tc.auth {
+			name += " with --auth"
+		}
+		t.Run("should generate the "+name+" template", func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "acme-crm")
+			files, err := Generate(dir, Options{Name: "acme-crm", Template: tc.template, Auth: tc.auth})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !slices.Equal(files, tc.files) {
+				t.Errorf("Expected files %v, got %v", tc.files, files)
+			}
+			fset := token.NewFileSet()
+			for _, f := range files {
+				if !strings.HasSuffix(f, ".go") {
+					continue
+				}
+				if _, err := parser.ParseFile(fset, filepath.Join(dir, f), nil, 0); err != nil {
+					t.Errorf("Expected %s to be valid Go, got %v", f, err)
+				}
+			}
+			plugin, _ := os.ReadFile(filepath.Join(dir, "plugin.go"))
+			for _, want := range []string{
+				"var Plugin typing.Plugin = &AcmeCrmPlugin{}",
+				`ID:              "acme-crm"`,
+				"ContractVersion: typing.ContractVersion",
+				"utils.PaginateCursor(contacts, params.Cursor, params.Limit)",
+			} {
+				if !strings.Contains(string(plugin), want) {
+					t.Errorf("Expected plugin.go to contain %q", want)
+				}
+			}
+			wantAuth := tc.auth || tc.template == "auth"
+			if got := strings.Contains(string(plugin), "models.AuthTypeAPIKey"); got != wantAuth {
+				t.Errorf("Expected API key auth type %v, got %v", wantAuth, got)
+			}
+		})
+	}
+
+	t.Run("should refuse a non-empty directory", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "keep.txt"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Generate(dir, Options{Name: "p", Template: "file"}); err == nil {
+			t.Errorf("Expected error for a non-empty directory")
+		}
+	})
+
+	t.Run("should reject unknown templates and invalid names", func(t *testing.T) {
+		if _, err := Generate(t.TempDir(), Options{Name: "p", Template: "grpc"}); err == nil {
+			t.Errorf("Expected error for unknown template")
+		}
+		for _, name := range []string{"", "Acme", "1plugin", "my plugin", "../x"} {
+			if err := ValidateName(name); err == nil {
+				t.Errorf("Expected error for name %q", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint restricts which versions are acceptable. The zero value accepts
+// every release.
+//
+// Pre-releases only satisfy a constraint when it names a pre-release of the
+// same MAJOR.MINOR.PATCH (">=1.3.0-rc.1" accepts 1.3.0-rc.2 but not
+// 1.4.0-rc.1), or when WithPrerelease was used.
+type Constraint struct {
+	raw        string
+	sets       [][]comparator // OR of ANDs
+	includePre bool
+}
+
+type comparator struct {
+	op string // one of = > >= < <=
+	v  Version
+	// explicitPre marks bounds the user wrote with a pre-release; generated
+	// bounds such as <2.0.0-0 do not unlock pre-releases.
+	explicitPre bool
+}
+
+// ParseConstraint accepts:
+//
+//	"", "*", "x", "latest"   any version
+//	"1.2.3", "=v1.2.3"       exactly that version
+//	"1.2", "1.x", "1"        any patch (or minor) of that release line
+//	"^1.2.3"                 compatible with 1.2.3: >=1.2.3 <2.0.0 (>=0.2.3 <0.3.0 for 0.x)
+//	"~1.2.3"                 patch updates only: >=1.2.3 <1.3.0
+//	">=1.0 <2.0", ">1.2, <=1.4"  all comparators must match
+//	"^1.2 || ^2.0"           either range
+func ParseConstraint(s string) (Constraint, error) {
+	raw := strings.TrimSpace(s)
+	c := Constraint{raw: raw}
+	if raw == "" || raw == "*" || raw == "x" || strings.EqualFold(raw, "latest") {
+		return c, nil
+	}
+	for _, part := range strings.Split(raw, "||") {
+		set, err := parseSet(part)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+func parseSet(s string) ([]comparator, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty range")
+	}
+	var set []comparator
+	for i := 0; i < len(fields); i++ {
+		tok := fields[i]
+		// Allow a space between operator and version: ">= 1.0".
+		if strings.Trim(tok, "<>=^~") == "" && i+1 < len(fields) {
+			i++
+			tok += fields[i]
+		}
+		cs, err := parseComparator(tok)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cs...)
+	}
+	return set, nil
+}
+
+// parseComparator expands one token into primitive comparators.
+func parseComparator(tok string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(tok, candidate) {
+			op, tok = candidate, tok[len(candidate):]
+			break
+		}
+	}
+	if tok == "" {
+		return nil, fmt.Errorf("missing version after %q", op)
+	}
+	v, n, err := parse(tok)
+	if err != nil {
+		return nil, err
+	}
+	pre := v.Pre != ""
+	v.Build = ""
+	if n == 0 { // "*" or "x"
+		if op == "^" || op == "~" {
+			return nil, fmt.Errorf("%q needs a version", op)
+		}
+		if op == "<" || op == ">" {
+			return []comparator{{op: "<", v: Version{Pre: "0"}}}, nil // matches nothing
+		}
+		return nil, nil
+	}
+
+	// upper returns the first version above the release line kept by n
+	// components, as an exclusive bound that also excludes its pre-releases.
+	upper := func(keep int) Version {
+		switch keep {
+		case 1:
+			return Version{Major: v.Major + 1, Pre: "0"}
+		case 2:
+			return Version{Major: v.Major, Minor: v.Minor + 1, Pre: "0"}
+		default:
+			return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, Pre: "0"}
+		}
+	}
+	lo := comparator{op: ">=", v: v, explicitPre: pre}
+
+	switch op {
+	case "^":
+		keep := 1
+		switch {
+		case v.Major > 0 || n == 1:
+		case v.Minor > 0 || n == 2:
+			keep = 2
+		default:
+			keep = 3
+		}
+		return []comparator{lo, {op: "<", v: upper(keep)}}, nil
+	case "~":
+		keep := 2
+		if n == 1 {
+			keep = 1
+		}
+		return []comparator{lo, {op: "<", v: upper(keep)}}, nil
+	case "", "=":
+		if n == 3 {
+			return []comparator{{op: "=", v: v, explicitPre: pre}}, nil
+		}
+		return []comparator{lo, {op: "<", v: upper(n)}}, nil
+	case ">=":
+		return []comparator{lo}, nil
+	case ">":
+		if n == 3 {
+			return []comparator{{op: ">", v: v, explicitPre: pre}}, nil
+		}
+		return []comparator{{op: ">=", v: upper(n)}}, nil
+	case "<":
+		if n == 3 {
+			return []comparator{{op: "<", v: v, explicitPre: pre}}, nil
+		}
+		return []comparator{{op: "<", v: Version{Major: v.Major, Minor: v.Minor, Pre: "0"}}}, nil
+	case "<=":
+		if n == 3 {
+			return []comparator{{op: "<=", v: v, explicitPre: pre}}, nil
+		}
+		return []comparator{{op: "<", v: upper(n)}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+func (c comparator) matches(v Version) bool {
+	d := v.Compare(c.v)
+	switch c.op {
+	case "=":
+		return d == 0
+	case ">":
+		return d > 0
+	case ">=":
+		return d >= 0
+	case "<":
+		return d < 0
+	case "<=":
+		return d <= 0
+	}
+	return false
+}
+
+// WithPrerelease returns a copy of c that also accepts pre-releases within
+// its ranges (the --pre flag).
+func (c Constraint) WithPrerelease() Constraint {
+	c.includePre = true
+	return c
+}
+
+// Check reports whether v satisfies c.
+func (c Constraint) Check(v Version) bool {
+	if len(c.sets) == 0 {
+		return !v.IsPrerelease() || c.includePre
+	}
+	for _, set := range c.sets {
+		if c.setMatches(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Constraint) setMatches(set []comparator, v Version) bool {
+	for _, cmp := range set {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() || c.includePre {
+		return true
+	}
+	for _, cmp := range set {
+		if cmp.explicitPre && cmp.v.Major == v.Major && cmp.v.Minor == v.Minor && cmp.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAny reports whether c places no bound on the version.
+func (c Constraint) IsAny() bool {
+	return len(c.sets) == 0
+}
+
+// String returns the constraint as written.
+func (c Constraint) String() string {
+	return c.raw
+}
@@ -0,0 +1,196 @@
+// Package semver implements SemVer 2.0 versions and the version ranges used
+// by install, update and plugin manifests. typing.ParseSemVer and
+// typing.CompareSemVer are built on it.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a MAJOR.MINOR.PATCH[-PRE][+BUILD] version.
+type Version struct {
+	Major, Minor, Patch int
+	// Pre holds the dot-separated pre-release identifiers, e.g. "rc.1".
+	Pre string
+	// Build holds build metadata; it never affects precedence.
+	Build string
+}
+
+// Parse parses a full version such as "1.2.3", "v1.3.0-rc.1" or "1.0.0+sha.abc".
+func Parse(s string) (Version, error) {
+	v, n, err := parse(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if n != 3 {
+		return Version{}, fmt.Errorf("invalid version format: %s", s)
+	}
+	return v, nil
+}
+
+// ParseLenient parses partial or sloppy versions ("2", "2.1", "v2.1.0-rc")
+// the way contract versions are read: missing or malformed numbers are 0.
+func ParseLenient(s string) Version {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s, v.Build = s[:i], s[i+1:]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, v.Pre = s[:i], s[i+1:]
+	}
+	parts := strings.Split(s, ".")
+	get := func(i int) int {
+		if i >= len(parts) {
+			return 0
+		}
+		n, _ := strconv.Atoi(parts[i])
+		return n
+	}
+	v.Major, v.Minor, v.Patch = get(0), get(1), get(2)
+	return v
+}
+
+// parse reads a possibly partial version and returns how many numeric
+// components were present. Wildcards ("x", "X", "*") end the version early.
+func parse(s string) (Version, int, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s, v.Build = s[:i], s[i+1:]
+		if err := checkIdentifiers(v.Build, false); err != nil {
+			return Version{}, 0, fmt.Errorf("invalid build metadata in %s: %w", raw, err)
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, v.Pre = s[:i], s[i+1:]
+		if err := checkIdentifiers(v.Pre, true); err != nil {
+			return Version{}, 0, fmt.Errorf("invalid pre-release in %s: %w", raw, err)
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version format: %s", raw)
+	}
+	nums := [3]int{}
+	n := 0
+	for _, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		num, err := strconv.Atoi(p)
+		if err != nil || num < 0 || (len(p) > 1 && p[0] == '0') {
+			return Version{}, 0, fmt.Errorf("invalid version %s: invalid number %q", raw, p)
+		}
+		nums[n] = num
+		n++
+	}
+	if v.Pre != "" && n != 3 {
+		return Version{}, 0, fmt.Errorf("invalid version %s: pre-release needs a full version", raw)
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, n, nil
+}
+
+func checkIdentifiers(s string, noLeadingZero bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return fmt.Errorf("invalid character %q", r)
+			}
+		}
+		if noLeadingZero && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("numeric identifier %q has a leading zero", id)
+		}
+	}
+	return nil
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Compare returns -1, 0 or 1 when v has lower, equal or higher precedence
+// than o. A pre-release sorts before its release (1.0.0-rc.1 < 1.0.0) and
+// pre-release identifiers compare numerically or lexically per SemVer 2.0.
+func (v Version) Compare(o Version) int {
+	switch {
+	case v.Major != o.Major:
+		return cmpInt(v.Major, o.Major)
+	case v.Minor != o.Minor:
+		return cmpInt(v.Minor, o.Minor)
+	case v.Patch != o.Patch:
+		return cmpInt(v.Patch, o.Patch)
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+func comparePre(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		if x == y {
+			continue
+		}
+		xn, yn := isNumeric(x), isNumeric(y)
+		switch {
+		case xn && yn:
+			xi, _ := strconv.Atoi(x)
+			yi, _ := strconv.Atoi(y)
+			return cmpInt(xi, yi)
+		case xn:
+			return -1 // numeric identifiers sort before alphanumeric ones
+		case yn:
+			return 1
+		default:
+			return strings.Compare(x, y)
+		}
+	}
+	return cmpInt(len(as), len(bs))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsPrerelease reports whether v carries pre-release identifiers.
+func (v Version) IsPrerelease() bool {
+	return v.Pre != ""
+}
+
+// String formats v as a tag, e.g. "v1.2.3" or "v1.3.0-rc.1".
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
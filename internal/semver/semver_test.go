@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("should parse with and without v prefix", func(t *testing.T) {
+		for _, s := range []string{"1.2.3", "v1.2.3"} {
+			v, err := Parse(s)
+			if err != nil {
+				t.Fatalf("Expected no error for %s, got %v", s, err)
+			}
+			if v != (Version{Major: 1, Minor: 2, Patch: 3}) {
+				t.Errorf("Expected 1.2.3, got %+v", v)
+			}
+		}
+	})
+
+	t.Run("should parse pre-release and build metadata", func(t *testing.T) {
+		v, err := Parse("v1.3.0-rc.1+build.5")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if v.Pre != "rc.1" || v.Build != "build.5" || !v.IsPrerelease() {
+			t.Errorf("Expected pre rc.1 and build build.5, got %+v", v)
+		}
+		if v.String() != "v1.3.0-rc.1+build.5" {
+			t.Errorf("Expected v1.3.0-rc.1+build.5, got %s", v.String())
+		}
+	})
+
+	t.Run("should reject malformed versions", func(t *testing.T) {
+		for _, s := range []string{"", "1.2", "1.2.x", "v1.2.3.4", "-1.0.0", "01.2.3", "1.2.3-", "1.2.3-rc..1", "1.2.3-01", "1.2.3-rc_1"} {
+			if _, err := Parse(s); err == nil {
+				t.Errorf("Expected error for %q", s)
+			}
+		}
+	})
+
+	t.Run("should parse leniently for contract versions", func(t *testing.T) {
+		cases := map[string]Version{
+			"2":          {Major: 2},
+			"2.1":        {Major: 2, Minor: 1},
+			"v2.1.0-rc1": {Major: 2, Minor: 1, Pre: "rc1"},
+			"":           {},
+			"x.y.z":      {},
+		}
+		for in, want := range cases {
+			if got := ParseLenient(in); got != want {
+				t.Errorf("ParseLenient(%q): expected %+v, got %+v", in, want, got)
+			}
+		}
+	})
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("should compare numerically", func(t *testing.T) {
+		a, _ := Parse("v1.10.0")
+		b, _ := Parse("v1.9.9")
+		if a.Compare(b) != 1 || b.Compare(a) != -1 || a.Compare(a) != 0 {
+			t.Errorf("Expected v1.10.0 > v1.9.9")
+		}
+	})
+
+	t.Run("should order pre-releases per SemVer 2.0", func(t *testing.T) {
+		// Example ordering from semver.org section 11.
+		ordered := []string{
+			"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+			"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+		}
+		var versions []Version
+		for i := len(ordered) - 1; i >= 0; i-- {
+			v, err := Parse(ordered[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			versions = append(versions, v)
+		}
+		slices.SortFunc(versions, Version.Compare)
+		for i, v := range versions {
+			if v.String() != "v"+ordered[i] {
+				t.Errorf("Expected position %d to be %s, got %s", i, ordered[i], v)
+			}
+		}
+	})
+
+	t.Run("should ignore build metadata", func(t *testing.T) {
+		a, _ := Parse("1.0.0+a")
+		b, _ := Parse("1.0.0+b")
+		if a.Compare(b) != 0 {
+			t.Errorf("Expected build metadata to be ignored")
+		}
+	})
+}
+
+func TestConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		match      []string
+		reject     []string
+	}{
+		{"", []string{"0.0.1", "9.9.9"}, []string{"1.0.0-rc.1"}},
+		{"latest", []string{"1.0.0"}, nil},
+		{"*", []string{"1.0.0"}, []string{"2.0.0-beta"}},
+		{"^1.2", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0", "2.0.0-rc.1", "1.5.0-rc.1"}},
+		{"^1.2.3", []string{"1.2.3", "1.3.0"}, []string{"1.2.2", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{"~1.4.0", []string{"1.4.0", "1.4.7"}, []string{"1.5.0", "1.3.9"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.2", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{"1.x", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1", []string{"1.0.0", "1.5.5"}, []string{"2.0.0", "0.9.0"}},
+		{"v1.2.3", []string{"1.2.3"}, []string{"1.2.4", "1.2.2"}},
+		{">=1.0 <2.0", []string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0", "2.0.0-rc.1"}},
+		{">= 1.0.0, < 1.5", []string{"1.4.9"}, []string{"1.5.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9", "0.1.0"}, []string{"1.3.0"}},
+		{"^1.2 || ^3.0", []string{"1.4.0", "3.1.0"}, []string{"2.0.0"}},
+		{">=1.3.0-rc.1 <2", []string{"1.3.0-rc.2", "1.3.0", "1.8.0"}, []string{"1.3.0-beta", "1.4.0-rc.1"}},
+		{"1.3.0-rc.1", []string{"1.3.0-rc.1"}, []string{"1.3.0-rc.2", "1.3.0"}},
+	}
+	for _, tc := range cases {
+		t.Run("should evaluate "+tc.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tc.constraint)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, s := range tc.match {
+				v, _ := Parse(s)
+				if !c.Check(v) {
+					t.Errorf("Expected %s to satisfy %q", s, tc.constraint)
+				}
+			}
+			for _, s := range tc.reject {
+				v, _ := Parse(s)
+				if c.Check(v) {
+					t.Errorf("Expected %s not to satisfy %q", s, tc.constraint)
+				}
+			}
+		})
+	}
+
+	t.Run("should accept pre-releases in range with WithPrerelease", func(t *testing.T) {
+		c, _ := ParseConstraint("^1.2")
+		pre := c.WithPrerelease()
+		rc, _ := Parse("1.5.0-rc.1")
+		next, _ := Parse("2.0.0-rc.1")
+		if !pre.Check(rc) {
+			t.Errorf("Expected 1.5.0-rc.1 to satisfy ^1.2 with pre-releases")
+		}
+		if pre.Check(next) {
+			t.Errorf("Expected 2.0.0-rc.1 to stay outside ^1.2")
+		}
+		any, _ := ParseConstraint("latest")
+		if !any.WithPrerelease().Check(next) {
+			t.Errorf("Expected latest with pre-releases to accept 2.0.0-rc.1")
+		}
+	})
+
+	t.Run("should reject invalid constraints", func(t *testing.T) {
+		for _, s := range []string{"^y", "^x", "~*", "1.2.3.4", ">>1", "main", "deadbeef", "1.2 ||", ">="} {
+			if _, err := ParseConstraint(s); err == nil {
+				t.Errorf("Expected error for %q", s)
+			}
+		}
+	})
+}
@@ -8,28 +8,49 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 	"time"
 
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/buildinfo"
+	"github.com/nikhiljohn10/uagplugin/internal/semver"
 	"github.com/nikhiljohn10/uagplugin/logger"
 )
 
 // BuildAndLog is a wrapper around BuildPlugin that logs errors.
-func BuildAndLog(ctx context.Context, pluginName, srcDir, buildFrom string) {
+// It returns the path of the compiled plugin.
+func BuildAndLog(ctx context.Context, pluginName, srcDir, buildFrom string, transport host.Transport) (string, error) {
 	buildDir, err := GetBuildDir()
 	if err != nil {
 		logger.Error("Failed to get plugin build dir: %v", err)
-		return
+		return "", err
 	}
-	err = BuildPlugin(ctx, pluginName, srcDir, buildDir, buildFrom == "repo")
+	artifact, err := BuildPlugin(ctx, pluginName, srcDir, buildDir, transport, buildFrom == "repo")
 	if err != nil {
 		logger.Error("Failed to build plugin: %v", err)
 	}
+	return artifact, err
+}
+
+// GoVersion returns the version of the go toolchain used for builds.
+func GoVersion(ctx context.Context) string {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOVERSION").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
 }
 
-// BuildPlugin builds a Go plugin from source.
-func BuildPlugin(ctx context.Context, pluginName, sourceDir, buildDir string, cleanup ...bool) error {
-	logger.Info("Building plugin as shared object file...")
+// BuildPlugin builds a Go plugin from source, either as a shared object
+// (native transport) or as a standalone executable (RPC transport), and
+// returns the path of the compiled file.
+func BuildPlugin(ctx context.Context, pluginName, sourceDir, buildDir string, transport host.Transport, cleanup ...bool) (string, error) {
+	if transport == host.TransportRPC {
+		logger.Info("Building plugin as RPC executable...")
+	} else {
+		logger.Info("Building plugin as shared object file...")
+	}
 
 	_, err1 := os.Stat(filepath.Join(sourceDir, "go.mod"))
 	_, err2 := os.Stat(filepath.Join(sourceDir, "plugin.go"))
@@ -38,35 +59,43 @@ func BuildPlugin(ctx context.Context, pluginName, sourceDir, buildDir string, cl
 		if len(cleanup) > 0 && cleanup[0] {
 			if err := os.RemoveAll(sourceDir); err != nil {
 				logger.Error("Failed to remove plugin directory: %v", err)
-				return err
+				return "", err
 			}
 		}
-		return fmt.Errorf("not a UAG plugin")
+		return "", fmt.Errorf("not a UAG plugin")
 	}
 
 	projectRoot, err := os.Getwd()
 	if err != nil {
 		logger.Error("Failed to locate project root: %v", err)
-		return err
+		return "", err
 	}
 	projectRoot, err = filepath.Abs(projectRoot)
 	if err != nil {
 		logger.Error("Failed to resolve project root path: %v", err)
-		return err
+		return "", err
 	}
 
-	if err := ensureLocalModule(ctx, sourceDir, projectRoot); err != nil {
-		return err
+	absSource, err := filepath.Abs(sourceDir)
+	if err != nil {
+		logger.Error("Failed to resolve plugin source path: %v", err)
+		return "", err
+	}
+	workDir, err := prepareWorkspace(ctx, pluginName, absSource, projectRoot)
+	if err != nil {
+		logger.Error("Failed to prepare build workspace: %v", err)
+		return "", err
 	}
+	defer os.RemoveAll(workDir)
 
-	soFile, err := filepath.Abs(filepath.Join(buildDir, pluginName+".so"))
+	soFile, err := filepath.Abs(filepath.Join(buildDir, pluginName+transport.Suffix()))
 	if err != nil {
-		logger.Error("Failed to resolve absolute path for plugin .so file: %v", err)
-		return err
+		logger.Error("Failed to resolve absolute path for plugin file: %v", err)
+		return "", err
 	}
 	if err := os.MkdirAll(filepath.Dir(soFile), 0755); err != nil {
 		logger.Error("Failed to create compiled plugins directory: %v", err)
-		return err
+		return "", err
 	}
 	// Enforce a build timeout to avoid indefinite hangs
 	var cancel context.CancelFunc
@@ -74,24 +103,52 @@ func BuildPlugin(ctx context.Context, pluginName, sourceDir, buildDir string, cl
 		ctx, cancel = context.WithTimeout(ctx, 2*time.Minute)
 		defer cancel()
 	}
-	cmdBuild := exec.CommandContext(ctx, "go", "build", "-buildmode=plugin", "-o", soFile, ".")
-	cmdBuild.Dir = sourceDir
+	// The workspace has no VCS metadata; the registry records the commit.
+	buildArgs := []string{"build", "-buildmode=plugin", "-buildvcs=false", "-o", soFile}
+	if hostTrimpath() {
+		// plugin.Open needs the host's -trimpath setting. With it, the
+		// workspace path under $HOME is stripped and checksums reproduce
+		// across machines.
+		buildArgs = append(buildArgs, "-trimpath")
+	}
+	if transport == host.TransportRPC {
+		// Executables don't share packages with the host, so strip local
+		// paths to make the artifact reproducible (and its checksum lockable).
+		buildArgs = []string{"build", "-trimpath", "-buildvcs=false", "-o", soFile}
+		overlay, cleanupOverlay, err := rpcMainOverlay(workDir)
+		if err != nil {
+			logger.Error("Failed to prepare RPC entrypoint: %v", err)
+			return "", err
+		}
+		defer cleanupOverlay()
+		if overlay != "" {
+			buildArgs = append(buildArgs, "-overlay", overlay)
+		}
+	}
+	cmdBuild := goCommand(ctx, workDir, append(buildArgs, ".")...)
 	cmdBuild.Stdout = os.Stdout
 	cmdBuild.Stderr = os.Stderr
 	if err := cmdBuild.Run(); err != nil {
-		logger.Error("Failed to build plugin as .so file: %v", err)
-		return err
+		logger.Error("Failed to build plugin file: %v", err)
+		return "", err
 	}
+	recordBuildInfo(soFile)
 	logger.Info("Done.\nPlugin Installed Location: %s", soFile)
-	return nil
+	return soFile, nil
 }
 
-func ensureLocalModule(ctx context.Context, sourceDir, projectRoot string) error {
-	modulePath := "github.com/nikhiljohn10/uagplugin"
+// hostTrimpath reports whether the running binary was built with -trimpath,
+// as release builds are. It is a variable so tests can override it.
+var hostTrimpath = func() bool {
+	bi, ok := debug.ReadBuildInfo()
+	return ok && buildinfo.FromBuildInfo(bi).Trimpath
+}
 
-	dropCmd := exec.CommandContext(ctx, "go", "mod", "edit", "-dropreplace="+modulePath)
-	dropCmd.Dir = sourceDir
-	_ = dropCmd.Run()
+// ensureLocalModule points the workspace at the uagplugin checkout the CLI is
+// run from, if any; otherwise the plugin's own requirement (and replace) is
+// kept. It then tidies the workspace module.
+func ensureLocalModule(ctx context.Context, sourceDir, projectRoot string) error {
+	modulePath := buildinfo.ModulePath
 
 	moduleRoot, err := findModuleRoot(projectRoot, modulePath)
 	if err != nil {
@@ -101,8 +158,7 @@ func ensureLocalModule(ctx context.Context, sourceDir, projectRoot string) error
 
 	if moduleRoot != "" {
 		replaceArg := fmt.Sprintf("-replace=%s=%s", modulePath, moduleRoot)
-		replaceCmd := exec.CommandContext(ctx, "go", "mod", "edit", replaceArg)
-		replaceCmd.Dir = sourceDir
+		replaceCmd := goCommand(ctx, sourceDir, "mod", "edit", replaceArg)
 		if output, err := replaceCmd.CombinedOutput(); err != nil {
 			logger.Error("Failed to align plugin module: %v", err)
 			if len(output) > 0 {
@@ -111,11 +167,10 @@ func ensureLocalModule(ctx context.Context, sourceDir, projectRoot string) error
 			return err
 		}
 	} else if logger.IsDebugMode() {
-		logger.Debug("Local module %q not found; relying on remote module", modulePath)
+		logger.Debug("Local module %q not found; using the plugin's requirement", modulePath)
 	}
 
-	tidyCmd := exec.CommandContext(ctx, "go", "mod", "tidy")
-	tidyCmd.Dir = sourceDir
+	tidyCmd := goCommand(ctx, sourceDir, "mod", "tidy")
 	tidyCmd.Stdout = os.Stdout
 	tidyCmd.Stderr = os.Stderr
 	if err := tidyCmd.Run(); err != nil {
@@ -125,6 +180,83 @@ func ensureLocalModule(ctx context.Context, sourceDir, projectRoot string) error
 	return nil
 }
 
+// InitPluginModule creates go.mod for a new plugin in dir. The plugin
+// requires uagplugin from the checkout the CLI runs in, if any, otherwise the
+// CLI's own release (cliVersion) or the latest release, and is then tidied.
+func InitPluginModule(ctx context.Context, dir, modulePath, cliVersion string) error {
+	run := func(args ...string) error {
+		cmd := goCommand(ctx, dir, args...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("go %s: %w\n%s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	if err := run("mod", "init", modulePath); err != nil {
+		return err
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	moduleRoot, err := findModuleRoot(projectRoot, buildinfo.ModulePath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case moduleRoot != "":
+		// Plugins created inside the checkout (like examples/) get a
+		// relative replace so the tree stays portable.
+		target := moduleRoot
+		if strings.HasPrefix(dir, moduleRoot+string(filepath.Separator)) {
+			if rel, err := filepath.Rel(dir, moduleRoot); err == nil {
+				target = filepath.ToSlash(rel)
+			}
+		}
+		err = run("mod", "edit", "-require="+buildinfo.ModulePath+"@v0.0.0", "-replace="+buildinfo.ModulePath+"="+target)
+		if err != nil {
+			return err
+		}
+	case isRelease(cliVersion):
+		if err := run("mod", "edit", "-require="+buildinfo.ModulePath+"@"+cliVersion); err != nil {
+			return err
+		}
+	default:
+		if err := run("get", buildinfo.ModulePath+"@latest"); err != nil {
+			return err
+		}
+	}
+	return run("mod", "tidy")
+}
+
+// isRelease reports whether v is a tagged release rather than a development
+// or pseudo-version.
+func isRelease(v string) bool {
+	sv, err := semver.Parse(v)
+	return err == nil && strings.HasPrefix(v, "v") && !sv.IsPrerelease() && sv.Build == ""
+}
+
+// recordBuildInfo writes the artifact's sidecar with the module versions and
+// replace directives that went into the build.
+func recordBuildInfo(artifact string) {
+	info, err := buildinfo.FromArtifact(artifact)
+	if err != nil {
+		logger.Warn("Could not record build info: %v", err)
+		return
+	}
+	for _, m := range info.Replaces() {
+		logger.Info("Using %s", m)
+	}
+	if logger.IsDebugMode() {
+		for _, m := range info.Deps {
+			logger.Debug("Module %s", m)
+		}
+	}
+	if err := buildinfo.Write(artifact, info); err != nil {
+		logger.Warn("Could not record build info: %v", err)
+	}
+}
+
 func findModuleRoot(startDir, modulePath string) (string, error) {
 	dir := startDir
 	for {
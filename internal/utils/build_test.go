@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+)
+
+func TestBuildPluginReproducible(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds plugins with the go toolchain")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("-buildmode=plugin is not supported on Windows")
+	}
+	// Keep the real module and build caches when HOME moves.
+	for _, key := range []string{"GOMODCACHE", "GOCACHE", "GOPATH"} {
+		out, err := exec.Command("go", "env", key).Output()
+		if err != nil {
+			t.Fatalf("go env %s: %v", key, err)
+		}
+		t.Setenv(key, strings.TrimSpace(string(out)))
+	}
+	source, err := filepath.Abs(filepath.Join("..", "..", "examples", "uag-fileplugin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	build := func(t *testing.T, transport host.Transport) []string {
+		var sums []string
+		for _, user := range []string{"ann", "someone-else"} {
+			home := filepath.Join(t.TempDir(), user)
+			t.Setenv("HOME", home)
+			artifact, err := BuildPlugin(context.Background(), "fileplugin", source, filepath.Join(home, "out"), transport)
+			if err != nil {
+				t.Fatalf("BuildPlugin error: %v", err)
+			}
+			data, err := os.ReadFile(artifact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := sha256.Sum256(data)
+			sums = append(sums, hex.EncodeToString(sum[:]))
+		}
+		return sums
+	}
+
+	t.Run("should build identical native plugins from different workspace roots", func(t *testing.T) {
+		defer func(orig func() bool) { hostTrimpath = orig }(hostTrimpath)
+		hostTrimpath = func() bool { return true }
+		if sums := build(t, host.TransportNative); sums[0] != sums[1] {
+			t.Errorf("Expected the same checksum, got %s and %s", sums[0], sums[1])
+		}
+	})
+
+	t.Run("should build identical RPC executables from different workspace roots", func(t *testing.T) {
+		if sums := build(t, host.TransportRPC); sums[0] != sums[1] {
+			t.Errorf("Expected the same checksum, got %s and %s", sums[0], sums[1])
+		}
+	})
+}
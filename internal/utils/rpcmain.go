@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const rpcMainFile = "zz_uag_rpc_main.go"
+
+const rpcMainSource = `// Code generated by uagplugin. DO NOT EDIT.
+
+package main
+
+import "github.com/nikhiljohn10/uagplugin/pluginrpc"
+
+func main() { pluginrpc.Main(Plugin) }
+`
+
+// rpcMainOverlay writes a `go build -overlay` file that adds an RPC entrypoint
+// to the plugin package without touching the source tree. It returns an empty
+// path when the plugin already declares its own main function.
+func rpcMainOverlay(sourceDir string) (string, func(), error) {
+	noop := func() {}
+	hasMain, err := declaresMain(sourceDir)
+	if err != nil || hasMain {
+		return "", noop, err
+	}
+	tmp, err := os.MkdirTemp("", "uag-rpc-")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmp) }
+	mainPath := filepath.Join(tmp, rpcMainFile)
+	if err := os.WriteFile(mainPath, []byte(rpcMainSource), 0644); err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	overlay := map[string]map[string]string{
+		"Replace": {filepath.Join(sourceDir, rpcMainFile): mainPath},
+	}
+	data, err := json.Marshal(overlay)
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	overlayPath := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayPath, data, 0644); err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	return overlayPath, cleanup, nil
+}
+
+// declaresMain reports whether the package in dir already defines func main.
+func declaresMain(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return false, err
+		}
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
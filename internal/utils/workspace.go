@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/nikhiljohn10/uagplugin/logger"
+)
+
+// prepareWorkspace copies the plugin source into a build workspace and aligns
+// its go.mod there, so builds never modify the developer's tree. The
+// workspace path only depends on the plugin name: native plugins built
+// without -trimpath embed source paths, and a stable path keeps their
+// checksums reproducible on one machine.
+func prepareWorkspace(ctx context.Context, pluginName, sourceDir, projectRoot string) (string, error) {
+	baseDir, err := GetBaseDir()
+	if err != nil {
+		return "", err
+	}
+	workDir := filepath.Join(baseDir, "work", pluginName)
+	if err := os.RemoveAll(workDir); err != nil {
+		return "", fmt.Errorf("failed to clean build workspace: %w", err)
+	}
+	if err := copySource(sourceDir, workDir); err != nil {
+		return "", fmt.Errorf("failed to copy plugin source: %w", err)
+	}
+	if err := absolutizeReplaces(ctx, sourceDir, workDir); err != nil {
+		return "", err
+	}
+	if err := ensureLocalModule(ctx, workDir, projectRoot); err != nil {
+		return "", err
+	}
+	return workDir, nil
+}
+
+// copySource copies the source tree, skipping version control metadata and
+// preserving file modes and symlinks.
+func copySource(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir() && d.Name() == ".git" && path != src:
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case !d.Type().IsRegular():
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
+// absolutizeReplaces rewrites relative filesystem replace directives in the
+// workspace go.mod so they still point next to the original source.
+func absolutizeReplaces(ctx context.Context, sourceDir, workDir string) error {
+	out, err := goCommand(ctx, workDir, "mod", "edit", "-json").Output()
+	if err != nil {
+		return fmt.Errorf("failed to read plugin go.mod: %w", err)
+	}
+	var mod struct {
+		Replace []struct {
+			Old, New struct{ Path, Version string }
+		}
+	}
+	if err := json.Unmarshal(out, &mod); err != nil {
+		return fmt.Errorf("failed to parse plugin go.mod: %w", err)
+	}
+	for _, r := range mod.Replace {
+		if r.New.Version != "" || filepath.IsAbs(r.New.Path) {
+			continue // module replacement or already absolute
+		}
+		old := r.Old.Path
+		if r.Old.Version != "" {
+			old += "@" + r.Old.Version
+		}
+		abs := filepath.Join(sourceDir, r.New.Path)
+		logger.Debug("Rewriting replace %s => %s to %s", old, r.New.Path, abs)
+		if output, err := goCommand(ctx, workDir, "mod", "edit", "-replace="+old+"="+abs).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to rewrite replace for %s: %w: %s", old, err, output)
+		}
+	}
+	return nil
+}
+
+// goCommand runs the go tool in dir, ignoring any go.work around it.
+func goCommand(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	return cmd
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date without a time of day, as parsed from the string
+// date fields of LedgerEntry and LedgerQueryParams. Its text form is the
+// ISO 8601 extended date, "2006-01-02". The zero value is an unset date and
+// encodes as "".
+type Date struct {
+	// t is midnight UTC of the date; zero when unset.
+	t time.Time
+}
+
+// dateLayouts are the ISO 8601 forms ParseDate accepts. Timestamps keep the
+// date as written, in their own offset.
+var dateLayouts = []string{time.DateOnly, time.RFC3339Nano, "2006-01-02T15:04:05", "20060102"}
+
+// ParseDate parses an ISO 8601 date such as "2025-01-31", "20250131" or a
+// timestamp such as "2025-01-31T10:00:00Z", whose time of day is dropped.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date %q: expected an ISO 8601 date such as 2006-01-02", s)
+}
+
+// parseDateOrZero is ParseDate, except that blank text is an unset date.
+func parseDateOrZero(s string) (Date, error) {
+	if strings.TrimSpace(s) == "" {
+		return Date{}, nil
+	}
+	return ParseDate(s)
+}
+
+// MustParseDate is like ParseDate but panics on error. It is meant for
+// constants.
+func MustParseDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	return Date{t: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// IsZero reports whether the date is unset.
+func (d Date) IsZero() bool { return d.t.IsZero() }
+
+// Time returns midnight UTC of the date, or the zero time when d is unset.
+func (d Date) Time() time.Time { return d.t }
+
+// Compare returns -1, 0 or +1 as d is before, equal to or after o. Unset
+// dates sort before every other date.
+func (d Date) Compare(o Date) int { return d.t.Compare(o.t) }
+
+func (d Date) Before(o Date) bool { return d.Compare(o) < 0 }
+func (d Date) After(o Date) bool  { return d.Compare(o) > 0 }
+
+// String returns the text form; see Date.
+func (d Date) String() string {
+	if d.t.IsZero() {
+		return ""
+	}
+	return d.t.Format(time.DateOnly)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses text as ParseDate does; an empty string is an unset
+// date.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := parseDateOrZero(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
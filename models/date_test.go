@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Run("should accept ISO 8601 dates and timestamps", func(t *testing.T) {
+		cases := map[string]string{
+			"2025-01-31":                "2025-01-31",
+			" 2025-01-31 ":              "2025-01-31",
+			"20250131":                  "2025-01-31",
+			"2025-01-31T23:30:00Z":      "2025-01-31",
+			"2025-01-31T23:30:00-08:00": "2025-01-31",
+			"2025-01-31T08:00:00":       "2025-01-31",
+		}
+		for in, want := range cases {
+			d, err := ParseDate(in)
+			if err != nil || d.String() != want {
+				t.Errorf("Expected ParseDate(%q) = %s, got %s, %v", in, want, d, err)
+			}
+		}
+	})
+
+	t.Run("should reject other forms", func(t *testing.T) {
+		for _, in := range []string{"", "31/01/2025", "2025-02-30", "2025-1-5", "yesterday"} {
+			if _, err := ParseDate(in); err == nil {
+				t.Errorf("Expected an error for %q", in)
+			}
+		}
+	})
+
+	t.Run("should order dates", func(t *testing.T) {
+		a, b := MustParseDate("2025-01-31"), MustParseDate("2025-02-01")
+		if !a.Before(b) || !b.After(a) || a.Compare(MustParseDate("2025-01-31T12:00:00Z")) != 0 {
+			t.Errorf("Expected %s < %s", a, b)
+		}
+	})
+}
+
+func TestLedgerEntryDates(t *testing.T) {
+	t.Run("should parse entry dates", func(t *testing.T) {
+		var e LedgerEntry
+		if err := json.Unmarshal([]byte(`{"date":"2025-03-04T10:00:00Z"}`), &e); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		d, err := e.ParseDate()
+		if err != nil || d.String() != "2025-03-04" {
+			t.Errorf("Expected 2025-03-04, got %s, %v", d, err)
+		}
+		if d, err := e.ParseDueDate(); err != nil || !d.IsZero() {
+			t.Errorf("Expected an unset due date, got %s, %v", d, err)
+		}
+	})
+
+	t.Run("should omit empty due dates", func(t *testing.T) {
+		out, _ := json.Marshal(LedgerEntry{})
+		if string(out) != `{"id":0,"date":"","doc_type":"","amount":"0"}` {
+			t.Errorf("Expected no due_date, got %s", out)
+		}
+	})
+
+	t.Run("should reject malformed dates when decoding a Date", func(t *testing.T) {
+		var d Date
+		if err := json.Unmarshal([]byte(`"31/01/2025"`), &d); err == nil {
+			t.Error("Expected an error")
+		}
+		if err := json.Unmarshal([]byte(`""`), &d); err != nil || !d.IsZero() {
+			t.Errorf("Expected an unset date, got %s, %v", d, err)
+		}
+	})
+}
+
+func TestLedgerQueryParamsValidate(t *testing.T) {
+	t.Run("should accept open and ordered ranges", func(t *testing.T) {
+		for _, p := range []LedgerQueryParams{
+			{},
+			{StartDate: "2025-01-01"},
+			{StartDate: "2025-01-01", EndDate: "2025-01-01T18:00:00Z"},
+		} {
+			if err := p.Validate(); err != nil {
+				t.Errorf("Expected no error for %+v, got %v", p, err)
+			}
+		}
+	})
+
+	t.Run("should reject malformed dates and a start after the end", func(t *testing.T) {
+		for _, p := range []LedgerQueryParams{
+			{StartDate: "31/01/2025"},
+			{EndDate: "2025-13-01"},
+			{StartDate: "2025-02-01", EndDate: "2025-01-01"},
+		} {
+			if err := p.Validate(); err == nil {
+				t.Errorf("Expected an error for %+v", p)
+			}
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when amounts in different currencies are
+// added, subtracted or compared.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an arbitrary-precision decimal amount with an optional ISO 4217
+// currency code, used for every amount and balance in the models. Its
+// text form is the decimal, followed by the code when there is one:
+// "-120.50" or "-120.50 EUR". Trailing zeros are kept, so amounts
+// round-trip as the plugin wrote them. The zero value is 0 without a
+// currency.
+type Money struct {
+	// amount is a canonical decimal ("-12.30").
+	amount   string
+	currency string
+}
+
+// ParseMoney parses a decimal with an optional ISO 4217 code, such as
+// "1234.56", "-0.5" or "1234.56 USD".
+func ParseMoney(s string) (Money, error) {
+	m := Money{}
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return m, fmt.Errorf("invalid amount %q: expected a decimal and an optional currency code", s)
+	}
+	amount, ok := canonicalDecimal(fields[0])
+	if !ok {
+		return m, fmt.Errorf("invalid amount %q: %q is not a decimal number", s, fields[0])
+	}
+	m.amount = amount
+	if len(fields) == 2 {
+		if !isCurrencyCode(fields[1]) {
+			return Money{}, fmt.Errorf("invalid amount %q: %q is not an ISO 4217 currency code", s, fields[1])
+		}
+		m.currency = fields[1]
+	}
+	return m, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on error. It is meant for
+// constants.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewMoney returns the amount unscaled/10^scale in currency, which may be
+// empty: NewMoney(-1250, 2, "EUR") is -12.50 EUR.
+func NewMoney(unscaled int64, scale int, currency string) (Money, error) {
+	if scale < 0 {
+		return Money{}, fmt.Errorf("invalid scale %d", scale)
+	}
+	if currency != "" && !isCurrencyCode(currency) {
+		return Money{}, fmt.Errorf("%q is not an ISO 4217 currency code", currency)
+	}
+	return Money{amount: formatDecimal(big.NewInt(unscaled), scale), currency: currency}, nil
+}
+
+// isCurrencyCode reports whether s has the form of an ISO 4217 alphabetic
+// code. Codes are not checked against the list, which changes over time.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalDecimal validates s and drops a leading '+', redundant leading
+// zeros and the sign of zero.
+func canonicalDecimal(s string) (string, bool) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(s, ".")
+	if intPart == "" && frac == "" || !allDigits(intPart) || !allDigits(frac) {
+		return "", false
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	out := intPart
+	if hasDot && frac != "" {
+		out += "." + frac
+	}
+	if neg && strings.Trim(out, "0.") != "" {
+		out = "-" + out
+	}
+	return out, true
+}
+
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// decimal returns the amount as unscaled * 10^-scale.
+func (m Money) decimal() (*big.Int, int) {
+	s := m.amount
+	if s == "" {
+		return new(big.Int), 0
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	n, _ := new(big.Int).SetString(intPart+frac, 10)
+	return n, len(frac)
+}
+
+func formatDecimal(n *big.Int, scale int) string {
+	neg := n.Sign() < 0
+	digits := new(big.Int).Abs(n).String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// rescale returns n multiplied up from scale from to scale to (to >= from).
+func rescale(n *big.Int, from, to int) *big.Int {
+	if to == from {
+		return n
+	}
+	f := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil)
+	return new(big.Int).Mul(n, f)
+}
+
+// Currency returns the ISO 4217 code, or "" when none was given.
+func (m Money) Currency() string { return m.currency }
+
+// WithCurrency returns m in the given currency, e.g. to apply a ledger's
+// currency to amounts that do not carry one.
+func (m Money) WithCurrency(code string) (Money, error) {
+	if code != "" && !isCurrencyCode(code) {
+		return m, fmt.Errorf("%q is not an ISO 4217 currency code", code)
+	}
+	m.currency = code
+	return m, nil
+}
+
+// Rat returns the amount as a rational number.
+func (m Money) Rat() *big.Rat {
+	n, scale := m.decimal()
+	return new(big.Rat).SetFrac(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+}
+
+// Sign returns -1, 0 or +1.
+func (m Money) Sign() int {
+	n, _ := m.decimal()
+	return n.Sign()
+}
+
+// IsZero reports whether the amount is zero, in any currency.
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	n, scale := m.decimal()
+	m.amount = formatDecimal(n.Neg(n), scale)
+	return m
+}
+
+// Abs returns |m|.
+func (m Money) Abs() Money {
+	if m.Sign() < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Add returns m + o, with the larger of the two scales. An amount without
+// a currency takes the other's; different currencies are an error.
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.common(o)
+	if err != nil {
+		return Money{}, err
+	}
+	a, as := m.decimal()
+	b, bs := o.decimal()
+	scale := max(as, bs)
+	sum := new(big.Int).Add(rescale(a, as, scale), rescale(b, bs, scale))
+	return Money{amount: formatDecimal(sum, scale), currency: cur}, nil
+}
+
+// Sub returns m - o; see Add.
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+// Cmp compares m and o numerically, returning -1, 0 or +1, under the same
+// currency rules as Add.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.common(o); err != nil {
+		return 0, err
+	}
+	return m.Rat().Cmp(o.Rat()), nil
+}
+
+// Equal reports whether m and o are the same amount in the same currency;
+// 1.5 equals 1.50.
+func (m Money) Equal(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c == 0 && m.currency == o.currency
+}
+
+// common returns the currency of an operation on m and o.
+func (m Money) common(o Money) (string, error) {
+	switch {
+	case m.currency == "":
+		return o.currency, nil
+	case o.currency == "" || o.currency == m.currency:
+		return m.currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+}
+
+// String returns the text form; see Money.
+func (m Money) String() string {
+	amount := m.amount
+	if amount == "" {
+		amount = "0"
+	}
+	if m.currency == "" {
+		return amount
+	}
+	return amount + " " + m.currency
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses text as ParseMoney does; an empty string is zero.
+func (m *Money) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*m = Money{}
+		return nil
+	}
+	parsed, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Run("should canonicalise decimals", func(t *testing.T) {
+		cases := map[string]string{
+			"120.00":      "120.00",
+			"+7":          "7",
+			"-0012.50":    "-12.50",
+			".5":          "0.5",
+			"5.":          "5",
+			"-0.00":       "0.00",
+			"1.5 EUR":     "1.5 EUR",
+			" 99.99 USD ": "99.99 USD",
+			"123456789012345678901234567890.123456789": "123456789012345678901234567890.123456789",
+		}
+		for in, want := range cases {
+			m, err := ParseMoney(in)
+			if err != nil {
+				t.Errorf("ParseMoney(%q) error: %v", in, err)
+				continue
+			}
+			if got := m.String(); got != want {
+				t.Errorf("Expected ParseMoney(%q) = %q, got %q", in, want, got)
+			}
+		}
+	})
+
+	t.Run("should reject malformed amounts", func(t *testing.T) {
+		for _, in := range []string{"", "-", ".", "1.2.3", "$12", "1,200.00", "12 eur", "12 EURO", "12 EUR x", "1e3"} {
+			if _, err := ParseMoney(in); err == nil {
+				t.Errorf("Expected an error for %q", in)
+			}
+		}
+	})
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	t.Run("should add and subtract at the larger scale", func(t *testing.T) {
+		sum, err := MustParseMoney("1.5").Add(MustParseMoney("0.25 EUR"))
+		if err != nil || sum.String() != "1.75 EUR" {
+			t.Errorf("Expected 1.75 EUR, got %s, %v", sum, err)
+		}
+		diff, err := MustParseMoney("10").Sub(MustParseMoney("10.01"))
+		if err != nil || diff.String() != "-0.01" {
+			t.Errorf("Expected -0.01, got %s, %v", diff, err)
+		}
+	})
+
+	t.Run("should refuse to mix currencies", func(t *testing.T) {
+		if _, err := MustParseMoney("1 EUR").Add(MustParseMoney("1 USD")); !errors.Is(err, ErrCurrencyMismatch) {
+			t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should compare numerically", func(t *testing.T) {
+		if c, err := MustParseMoney("1.50").Cmp(MustParseMoney("1.5")); c != 0 || err != nil {
+			t.Errorf("Expected 1.50 == 1.5, got %d, %v", c, err)
+		}
+		if c, _ := MustParseMoney("-2").Cmp(MustParseMoney("1")); c != -1 {
+			t.Errorf("Expected -2 < 1, got %d", c)
+		}
+		if MustParseMoney("1 EUR").Equal(MustParseMoney("1")) {
+			t.Error("Expected amounts in different currencies to differ")
+		}
+	})
+
+	t.Run("should negate and take absolute values", func(t *testing.T) {
+		m := MustParseMoney("12.30 GBP")
+		if got := m.Neg().String(); got != "-12.30 GBP" {
+			t.Errorf("Expected -12.30 GBP, got %s", got)
+		}
+		if got := m.Neg().Abs().String(); got != "12.30 GBP" {
+			t.Errorf("Expected 12.30 GBP, got %s", got)
+		}
+	})
+
+	t.Run("should build from minor units", func(t *testing.T) {
+		m, err := NewMoney(-1250, 2, "EUR")
+		if err != nil || m.String() != "-12.50 EUR" {
+			t.Errorf("Expected -12.50 EUR, got %s, %v", m, err)
+		}
+		if m, _ := NewMoney(5, 3, ""); m.String() != "0.005" {
+			t.Errorf("Expected 0.005, got %s", m)
+		}
+	})
+}
+
+func TestMoneyJSON(t *testing.T) {
+	t.Run("should read and write amounts as strings", func(t *testing.T) {
+		var l Ledger
+		if err := json.Unmarshal([]byte(`{"opening_balance":"","entries":[{"id":1,"amount":"-120.00 EUR","balance":"5"}]}`), &l); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if e := l.Entries[0]; e.Amount.String() != "-120.00 EUR" || e.Balance == nil || e.Balance.String() != "5" || !l.OpeningBalance.IsZero() {
+			t.Errorf("Expected -120.00 EUR with balance 5 from zero, got %+v", l)
+		}
+		data, err := json.Marshal(l.Entries[0])
+		if err != nil || !strings.Contains(string(data), `"amount":"-120.00 EUR"`) {
+			t.Errorf("Expected the amount as a string, got %s, %v", data, err)
+		}
+		if err := json.Unmarshal([]byte(`{"id":1,"amount":"$1,200"}`), &LedgerEntry{}); err == nil {
+			t.Error("Expected an error for a malformed amount")
+		}
+	})
+
+	t.Run("should reject malformed text and decode empty text as zero", func(t *testing.T) {
+		var m Money
+		if err := json.Unmarshal([]byte(`"$1,200"`), &m); err == nil {
+			t.Error("Expected an error for a malformed amount")
+		}
+		if err := json.Unmarshal([]byte(`""`), &m); err != nil || !m.IsZero() {
+			t.Errorf("Expected zero, got %s, %v", m, err)
+		}
+	})
+}
@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 type AuthParams struct {
 	APIKey       string             `json:"api_key"`
 	ClientID     string             `json:"client_id"`
@@ -25,8 +27,36 @@ type ContactQueryParams struct {
 // Sorted by timestamp ascending
 type LedgerQueryParams struct {
 	CommonParams
-	CustomerID string    `json:"customer_id"`
-	StartDate  string    `json:"start_date"`
-	EndDate    string    `json:"end_date"`
-	DocTypes   []DocType `json:"doc_types"`
+	CustomerID string `json:"customer_id"`
+	// StartDate and EndDate are ISO 8601 dates bounding the entry dates,
+	// inclusive; either may be empty. See ParseDateRange and
+	// utils.FilterByDateRange.
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	DocTypes  []DocType `json:"doc_types"`
+}
+
+// ParseDateRange parses StartDate and EndDate; an empty one is an unset
+// Date. It does not check their order; see Validate.
+func (p LedgerQueryParams) ParseDateRange() (start, end Date, err error) {
+	if start, err = parseDateOrZero(p.StartDate); err != nil {
+		return start, end, fmt.Errorf("start_date: %w", err)
+	}
+	if end, err = parseDateOrZero(p.EndDate); err != nil {
+		return start, end, fmt.Errorf("end_date: %w", err)
+	}
+	return start, end, nil
+}
+
+// Validate checks that the dates parse and that StartDate is not after
+// EndDate.
+func (p LedgerQueryParams) Validate() error {
+	start, end, err := p.ParseDateRange()
+	if err != nil {
+		return err
+	}
+	if !start.IsZero() && !end.IsZero() && start.After(end) {
+		return fmt.Errorf("start_date %s is after end_date %s", p.StartDate, p.EndDate)
+	}
+	return nil
 }
@@ -1,12 +1,17 @@
 package models
 
 import (
+	"slices"
 	"strings"
+	"time"
 )
 
 type DocType string
 type AuthType string
 
+// Direction is the side of the customer's account a LedgerEntry is posted to.
+type Direction string
+
 const (
 	DocTypeInvoice      DocType = "invoice"
 	DocTypePayment      DocType = "payment"
@@ -16,6 +21,9 @@ const (
 	DocTypeDebitNote    DocType = "debit_note"
 	DocTypeJournal      DocType = "journal"
 
+	DirectionDebit  Direction = "debit"
+	DirectionCredit Direction = "credit"
+
 	AuthTypeAPIKey AuthType = "api_key"
 	AuthTypeOAuth2 AuthType = "oauth2"
 	AuthTypeNone   AuthType = "none"
@@ -25,6 +33,25 @@ func (dt DocType) String() string {
 	return strings.ToTitle(strings.ReplaceAll(string(dt), "_", " "))
 }
 
+// DocTypes lists the known DocType values.
+var DocTypes = []DocType{DocTypeInvoice, DocTypePayment, DocTypeRefund, DocTypeCreditNote, DocTypeCreditRefund, DocTypeDebitNote, DocTypeJournal}
+
+// Directions lists the known Direction values.
+var Directions = []Direction{DirectionDebit, DirectionCredit}
+
+// Valid reports whether d is one of Directions, or empty.
+func (d Direction) Valid() bool {
+	return d == "" || slices.Contains(Directions, d)
+}
+
+// AuthTypes lists the known AuthType values.
+var AuthTypes = []AuthType{AuthTypeAPIKey, AuthTypeOAuth2, AuthTypeNone}
+
+// Valid reports whether at is one of AuthTypes.
+func (at AuthType) Valid() bool {
+	return slices.Contains(AuthTypes, at)
+}
+
 type MetaData struct {
 	ID              string           `json:"id"`
 	Name            string           `json:"name"`
@@ -35,12 +62,72 @@ type MetaData struct {
 	ContractVersion string           `json:"contract_version"`
 	AuthCredentials *AuthCredentials `json:"auth_credentials,omitempty"`
 	ApiCredentials  *ApiCredentials  `json:"api_credentials,omitempty"`
+	// OAuth2 describes the provider of an AuthTypeOAuth2 plugin so the host
+	// can run the authorization flow and refresh tokens. Added in contract 2.3.0.
+	OAuth2 *OAuth2Config `json:"oauth2,omitempty"`
+}
+
+// OAuth2Config describes an OAuth2 provider for the authorization code flow
+// with PKCE.
+type OAuth2Config struct {
+	AuthorizeURL string   `json:"authorize_url"`
+	TokenURL     string   `json:"token_url"`
+	Scopes       []string `json:"scopes,omitempty"`
+	// ClientID is an optional public client registered by the plugin author.
+	ClientID string `json:"client_id,omitempty"`
+	// AuthURLParams are added to the authorize URL (e.g. access_type=offline).
+	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 }
 
 type Contact struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+
+	// The fields below were added in contract 2.5.0 and are optional.
+
+	CompanyName     string   `json:"company_name,omitempty"`
+	Phones          []Phone  `json:"phones,omitempty"`
+	BillingAddress  *Address `json:"billing_address,omitempty"`
+	ShippingAddress *Address `json:"shipping_address,omitempty"`
+	// TaxID is the tax or VAT registration number.
+	TaxID string `json:"tax_id,omitempty"`
+	// Currency is the ISO 4217 code the contact is invoiced in.
+	Currency string `json:"currency,omitempty"`
+	// OutstandingBalance is what the contact owes; negative when in credit.
+	OutstandingBalance *Money     `json:"outstanding_balance,omitempty"`
+	CreatedAt          *time.Time `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	// Attributes holds platform-specific data with no field of its own.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Phone is a contact phone number. Type is a free-form label such as
+// "mobile", "work" or "fax".
+type Phone struct {
+	Type   string `json:"type,omitempty"`
+	Number string `json:"number"`
+}
+
+// Address is a postal address. Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// String returns the non-empty parts of a joined with ", ".
+func (a Address) String() string {
+	var parts []string
+	for _, p := range []string{a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
 }
 
 type Contacts struct {
@@ -51,15 +138,71 @@ type Contacts struct {
 }
 
 type LedgerEntry struct {
-	ID      int64   `json:"id"`
+	ID int64 `json:"id"`
+	// Date is an ISO 8601 date such as "2025-01-31"; see ParseDate.
 	Date    string  `json:"date"`
 	DocType DocType `json:"doc_type"`
-	Amount  string  `json:"amount"`
+	// Amount is positive for debits to the customer (invoices) and negative
+	// for credits (payments, credit notes). When Direction is set, its sign
+	// is taken from Direction instead; see Signed. It was a string before
+	// contract 3.0.0 and is still written as one in JSON.
+	Amount Money `json:"amount"`
+
+	// The fields below were added in contract 2.4.0 and are optional.
+
+	// Reference is the document number shown to the customer (e.g. "INV-0042").
+	Reference   string `json:"reference,omitempty"`
+	Description string `json:"description,omitempty"`
+	// DueDate is in the same form as Date; see ParseDueDate.
+	DueDate string `json:"due_date,omitempty"`
+	// Direction states whether the entry debits or credits the customer.
+	Direction Direction `json:"direction,omitempty"`
+	// Currency is the ISO 4217 code of Amount when Amount does not carry one.
+	Currency string `json:"currency,omitempty"`
+	// Balance is the running balance after this entry. When the plugin
+	// leaves it nil, host.FillBalances computes it for complete ledgers.
+	Balance *Money `json:"balance,omitempty"`
+	// LinkedIDs are the IDs of related entries, such as the invoices a
+	// payment was applied to.
+	LinkedIDs []int64 `json:"linked_ids,omitempty"`
+}
+
+// ParseDate parses Date. An empty Date is an unset date.
+func (e LedgerEntry) ParseDate() (Date, error) {
+	return parseDateOrZero(e.Date)
+}
+
+// ParseDueDate parses DueDate. An empty DueDate is an unset date.
+func (e LedgerEntry) ParseDueDate() (Date, error) {
+	return parseDateOrZero(e.DueDate)
+}
+
+// Signed returns Amount with the sign given by Direction, and in Currency
+// when Amount has no currency of its own.
+func (e LedgerEntry) Signed() Money {
+	m := e.Amount
+	switch e.Direction {
+	case DirectionDebit:
+		m = m.Abs()
+	case DirectionCredit:
+		m = m.Abs().Neg()
+	}
+	if m.Currency() == "" && e.Currency != "" {
+		if c, err := m.WithCurrency(e.Currency); err == nil {
+			m = c
+		}
+	}
+	return m
 }
 
 type Ledger struct {
-	Entries        []LedgerEntry `json:"entries"`
-	CustomerName   string        `json:"customer_name"`
-	OpeningBalance string        `json:"opening_balance"`
-	NextCursor     *string       `json:"next_cursor,omitempty"`
+	Entries      []LedgerEntry `json:"entries"`
+	CustomerName string        `json:"customer_name"`
+	// OpeningBalance was a string before contract 3.0.0, like
+	// LedgerEntry.Amount.
+	OpeningBalance Money `json:"opening_balance"`
+	// ClosingBalance is the balance after the last entry. Added in contract
+	// 2.4.0; host.FillBalances computes it like LedgerEntry.Balance.
+	ClosingBalance *Money  `json:"closing_balance,omitempty"`
+	NextCursor     *string `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,161 @@
+package plugingrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc/pb"
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+	"github.com/nikhiljohn10/uagplugin/typing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client talks to one plugin of a Server and implements typing.Plugin,
+// typing.ContextPlugin, typing.Authenticator and typing.Tester. Use
+// Capabilities to find out which optional interfaces the remote plugin
+// actually supports. Context deadlines are sent as gRPC deadlines.
+type Client struct {
+	rpc    pb.PluginServiceClient
+	plugin string
+	caps   pluginrpc.Capabilities
+	conn   *grpc.ClientConn
+}
+
+var _ typing.Plugin = (*Client)(nil)
+var _ typing.Authenticator = (*Client)(nil)
+var _ typing.Tester = (*Client)(nil)
+var _ typing.ContextPlugin = (*Client)(nil)
+
+// NewClient performs the capability handshake for plugin over conn. The
+// caller keeps ownership of conn.
+func NewClient(ctx context.Context, conn grpc.ClientConnInterface, plugin string) (*Client, error) {
+	c := &Client{rpc: pb.NewPluginServiceClient(conn), plugin: plugin}
+	caps, err := c.rpc.Capabilities(ctx, c.req())
+	if err != nil {
+		return nil, fmt.Errorf("plugin handshake failed: %w", fromStatus(err))
+	}
+	c.caps = pluginrpc.Capabilities{Authenticator: caps.GetAuthenticator(), Tester: caps.GetTester(), Context: true}
+	return c, nil
+}
+
+// Dial connects to the plugin named by a grpc://host:port/name target.
+// Close closes the connection.
+func Dial(ctx context.Context, target string) (*Client, error) {
+	addr, name, err := ParseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewClient(ctx, conn, name)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	c.conn = conn
+	return c, nil
+}
+
+func (c *Client) req() *pb.PluginRequest {
+	return &pb.PluginRequest{Plugin: c.plugin}
+}
+
+// Capabilities reports the optional interfaces implemented by the remote plugin.
+func (c *Client) Capabilities() pluginrpc.Capabilities { return c.caps }
+
+// Meta returns nil when the remote call fails; MetaContext reports why.
+func (c *Client) Meta() *models.MetaData {
+	meta, _ := c.MetaContext(context.Background())
+	return meta
+}
+
+// MetaContext returns the remote metadata, or the error of the call.
+func (c *Client) MetaContext(ctx context.Context) (*models.MetaData, error) {
+	meta, err := c.rpc.Meta(ctx, c.req())
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return metaFromPB(meta), nil
+}
+
+func (c *Client) Health() string {
+	return c.HealthContext(context.Background())
+}
+
+// HealthContext returns the remote status, or the error text when the call fails.
+func (c *Client) HealthContext(ctx context.Context) string {
+	reply, err := c.rpc.Health(ctx, c.req())
+	if err != nil {
+		return "error: " + fromStatus(err).Error()
+	}
+	return reply.GetStatus()
+}
+
+func (c *Client) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	return c.ContactsContext(context.Background(), auth, params)
+}
+
+func (c *Client) ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	reply, err := c.rpc.Contacts(ctx, &pb.ContactsRequest{Plugin: c.plugin, Auth: auth, Params: contactParamsToPB(params)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return contactsFromPB(reply)
+}
+
+func (c *Client) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	return c.LedgerContext(context.Background(), auth, params)
+}
+
+func (c *Client) LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	reply, err := c.rpc.Ledger(ctx, &pb.LedgerRequest{Plugin: c.plugin, Auth: auth, Params: ledgerParamsToPB(params)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return ledgerFromPB(reply)
+}
+
+func (c *Client) Auth(params models.AuthParams) (*models.AuthCredentials, error) {
+	return c.AuthContext(context.Background(), params)
+}
+
+// AuthContext is validated against the plugin's metadata on the server.
+func (c *Client) AuthContext(ctx context.Context, params models.AuthParams) (*models.AuthCredentials, error) {
+	if !c.caps.Authenticator {
+		return nil, pluginrpc.ErrUnsupported
+	}
+	reply, err := c.rpc.Auth(ctx, &pb.AuthRequest{Plugin: c.plugin, Params: authParamsToPB(params)})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	creds := models.AuthCredentials(reply.GetCredentials())
+	if creds == nil {
+		creds = models.AuthCredentials{}
+	}
+	return &creds, nil
+}
+
+func (c *Client) RunTests() error {
+	return c.RunTestsContext(context.Background())
+}
+
+func (c *Client) RunTestsContext(ctx context.Context) error {
+	if !c.caps.Tester {
+		return pluginrpc.ErrUnsupported
+	}
+	_, err := c.rpc.RunTests(ctx, c.req())
+	return fromStatus(err)
+}
+
+// Close closes the connection opened by Dial; it does nothing for clients
+// made with NewClient.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,326 @@
+package plugingrpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc/pb"
+)
+
+// Conversions between the models and their messages. Empty maps and slices
+// come back as nil, as protobuf does not tell them apart.
+
+func metaToPB(m *models.MetaData) *pb.MetaData {
+	if m == nil {
+		return nil
+	}
+	out := &pb.MetaData{
+		Id:              m.ID,
+		Name:            m.Name,
+		Version:         m.Version,
+		Description:     m.Description,
+		Author:          m.Author,
+		AuthType:        string(m.AuthType),
+		ContractVersion: m.ContractVersion,
+	}
+	if m.AuthCredentials != nil {
+		out.AuthCredentials = *m.AuthCredentials
+	}
+	if m.ApiCredentials != nil {
+		out.ApiCredentials = *m.ApiCredentials
+	}
+	if o := m.OAuth2; o != nil {
+		out.Oauth2 = &pb.OAuth2Config{
+			AuthorizeUrl:  o.AuthorizeURL,
+			TokenUrl:      o.TokenURL,
+			Scopes:        o.Scopes,
+			ClientId:      o.ClientID,
+			AuthUrlParams: o.AuthURLParams,
+		}
+	}
+	return out
+}
+
+func metaFromPB(m *pb.MetaData) *models.MetaData {
+	if m == nil {
+		return nil
+	}
+	out := &models.MetaData{
+		ID:              m.Id,
+		Name:            m.Name,
+		Version:         m.Version,
+		Description:     m.Description,
+		Author:          m.Author,
+		AuthType:        models.AuthType(m.AuthType),
+		ContractVersion: m.ContractVersion,
+	}
+	if len(m.AuthCredentials) > 0 {
+		creds := models.AuthCredentials(m.AuthCredentials)
+		out.AuthCredentials = &creds
+	}
+	if len(m.ApiCredentials) > 0 {
+		keys := models.ApiCredentials(m.ApiCredentials)
+		out.ApiCredentials = &keys
+	}
+	if o := m.Oauth2; o != nil {
+		out.OAuth2 = &models.OAuth2Config{
+			AuthorizeURL:  o.AuthorizeUrl,
+			TokenURL:      o.TokenUrl,
+			Scopes:        o.Scopes,
+			ClientID:      o.ClientId,
+			AuthURLParams: nilIfEmpty(o.AuthUrlParams),
+		}
+	}
+	return out
+}
+
+func authParamsToPB(p models.AuthParams) *pb.AuthParams {
+	out := &pb.AuthParams{ApiKey: p.APIKey, ClientId: p.ClientID, ClientSecret: p.ClientSecret}
+	if p.Data != nil {
+		out.Data = *p.Data
+	}
+	return out
+}
+
+func authParamsFromPB(p *pb.AuthParams) models.AuthParams {
+	out := models.AuthParams{APIKey: p.GetApiKey(), ClientID: p.GetClientId(), ClientSecret: p.GetClientSecret()}
+	if data := p.GetData(); len(data) > 0 {
+		out.Data = &data
+	}
+	return out
+}
+
+func commonToPB(c models.CommonParams) *pb.CommonParams {
+	return &pb.CommonParams{
+		Page:           int32(c.Page),
+		Cursor:         c.Cursor,
+		Limit:          int32(c.Limit),
+		SortDescending: c.SortDescending,
+		Extras:         c.Extras,
+	}
+}
+
+func commonFromPB(c *pb.CommonParams) models.CommonParams {
+	return models.CommonParams{
+		Page:           int(c.GetPage()),
+		Cursor:         c.GetCursor(),
+		Limit:          int(c.GetLimit()),
+		SortDescending: c.GetSortDescending(),
+		Extras:         nilIfEmpty(c.GetExtras()),
+	}
+}
+
+func contactParamsToPB(p models.ContactQueryParams) *pb.ContactQueryParams {
+	return &pb.ContactQueryParams{Common: commonToPB(p.CommonParams), Search: p.Search, SearchIds: p.SearchIDs}
+}
+
+func contactParamsFromPB(p *pb.ContactQueryParams) models.ContactQueryParams {
+	return models.ContactQueryParams{CommonParams: commonFromPB(p.GetCommon()), Search: p.GetSearch(), SearchIDs: p.GetSearchIds()}
+}
+
+func ledgerParamsToPB(p models.LedgerQueryParams) *pb.LedgerQueryParams {
+	out := &pb.LedgerQueryParams{Common: commonToPB(p.CommonParams), CustomerId: p.CustomerID, StartDate: p.StartDate, EndDate: p.EndDate}
+	for _, dt := range p.DocTypes {
+		out.DocTypes = append(out.DocTypes, string(dt))
+	}
+	return out
+}
+
+func ledgerParamsFromPB(p *pb.LedgerQueryParams) models.LedgerQueryParams {
+	out := models.LedgerQueryParams{CommonParams: commonFromPB(p.GetCommon()), CustomerID: p.GetCustomerId(), StartDate: p.GetStartDate(), EndDate: p.GetEndDate()}
+	for _, dt := range p.GetDocTypes() {
+		out.DocTypes = append(out.DocTypes, models.DocType(dt))
+	}
+	return out
+}
+
+func contactsToPB(c *models.Contacts) *pb.Contacts {
+	out := &pb.Contacts{Count: int32(c.Count), Total: int32(c.Total), NextCursor: c.NextCursor}
+	for _, item := range c.Items {
+		out.Contacts = append(out.Contacts, contactToPB(item))
+	}
+	return out
+}
+
+func contactsFromPB(c *pb.Contacts) (*models.Contacts, error) {
+	out := &models.Contacts{Count: int(c.GetCount()), Total: int(c.GetTotal()), NextCursor: c.NextCursor}
+	for _, item := range c.GetContacts() {
+		contact, err := contactFromPB(item)
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, contact)
+	}
+	return out, nil
+}
+
+func contactToPB(c models.Contact) *pb.Contact {
+	out := &pb.Contact{
+		Id:                 c.ID,
+		Name:               c.Name,
+		Email:              c.Email,
+		CompanyName:        c.CompanyName,
+		BillingAddress:     addressToPB(c.BillingAddress),
+		ShippingAddress:    addressToPB(c.ShippingAddress),
+		TaxId:              c.TaxID,
+		Currency:           c.Currency,
+		OutstandingBalance: moneyToPB(c.OutstandingBalance),
+		CreatedAt:          timeToPB(c.CreatedAt),
+		UpdatedAt:          timeToPB(c.UpdatedAt),
+		Attributes:         c.Attributes,
+	}
+	for _, p := range c.Phones {
+		out.Phones = append(out.Phones, &pb.Phone{Type: p.Type, Number: p.Number})
+	}
+	return out
+}
+
+func contactFromPB(c *pb.Contact) (models.Contact, error) {
+	balance, err := optionalMoneyFromPB(c.OutstandingBalance)
+	if err != nil {
+		return models.Contact{}, fmt.Errorf("contact %s: outstanding_balance: %w", c.GetId(), err)
+	}
+	out := models.Contact{
+		ID:                 c.GetId(),
+		Name:               c.GetName(),
+		Email:              c.GetEmail(),
+		CompanyName:        c.GetCompanyName(),
+		BillingAddress:     addressFromPB(c.GetBillingAddress()),
+		ShippingAddress:    addressFromPB(c.GetShippingAddress()),
+		TaxID:              c.GetTaxId(),
+		Currency:           c.GetCurrency(),
+		OutstandingBalance: balance,
+		CreatedAt:          timeFromPB(c.CreatedAt),
+		UpdatedAt:          timeFromPB(c.UpdatedAt),
+		Attributes:         nilIfEmpty(c.GetAttributes()),
+	}
+	for _, p := range c.GetPhones() {
+		out.Phones = append(out.Phones, models.Phone{Type: p.GetType(), Number: p.GetNumber()})
+	}
+	return out, nil
+}
+
+func addressToPB(a *models.Address) *pb.Address {
+	if a == nil {
+		return nil
+	}
+	return &pb.Address{Line1: a.Line1, Line2: a.Line2, City: a.City, Region: a.Region, PostalCode: a.PostalCode, Country: a.Country}
+}
+
+func addressFromPB(a *pb.Address) *models.Address {
+	if a == nil {
+		return nil
+	}
+	return &models.Address{Line1: a.GetLine1(), Line2: a.GetLine2(), City: a.GetCity(), Region: a.GetRegion(), PostalCode: a.GetPostalCode(), Country: a.GetCountry()}
+}
+
+func timeToPB(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339Nano)
+	return &s
+}
+
+// timeFromPB parses an RFC 3339 timestamp, dropping one that does not parse.
+func timeFromPB(s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func ledgerToPB(l *models.Ledger) *pb.Ledger {
+	out := &pb.Ledger{CustomerName: l.CustomerName, OpeningBalance: l.OpeningBalance.String(), NextCursor: l.NextCursor, ClosingBalance: moneyToPB(l.ClosingBalance)}
+	for _, e := range l.Entries {
+		out.Entries = append(out.Entries, &pb.LedgerEntry{
+			Id:          e.ID,
+			Date:        e.Date,
+			DocType:     string(e.DocType),
+			Amount:      e.Amount.String(),
+			Reference:   e.Reference,
+			Description: e.Description,
+			DueDate:     e.DueDate,
+			Direction:   string(e.Direction),
+			Currency:    e.Currency,
+			Balance:     moneyToPB(e.Balance),
+			LinkedIds:   e.LinkedIDs,
+		})
+	}
+	return out
+}
+
+func ledgerFromPB(l *pb.Ledger) (*models.Ledger, error) {
+	opening, err := moneyFromPB(l.GetOpeningBalance())
+	if err != nil {
+		return nil, fmt.Errorf("opening_balance: %w", err)
+	}
+	closing, err := optionalMoneyFromPB(l.ClosingBalance)
+	if err != nil {
+		return nil, fmt.Errorf("closing_balance: %w", err)
+	}
+	out := &models.Ledger{CustomerName: l.GetCustomerName(), OpeningBalance: opening, NextCursor: l.NextCursor, ClosingBalance: closing}
+	for _, e := range l.GetEntries() {
+		amount, err := moneyFromPB(e.GetAmount())
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", e.GetId(), err)
+		}
+		balance, err := optionalMoneyFromPB(e.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: balance: %w", e.GetId(), err)
+		}
+		out.Entries = append(out.Entries, models.LedgerEntry{
+			ID:          e.GetId(),
+			Date:        e.GetDate(),
+			DocType:     models.DocType(e.GetDocType()),
+			Amount:      amount,
+			Reference:   e.GetReference(),
+			Description: e.GetDescription(),
+			DueDate:     e.GetDueDate(),
+			Direction:   models.Direction(e.GetDirection()),
+			Currency:    e.GetCurrency(),
+			Balance:     balance,
+			LinkedIDs:   e.GetLinkedIds(),
+		})
+	}
+	return out, nil
+}
+
+func moneyToPB(m *models.Money) *string {
+	if m == nil {
+		return nil
+	}
+	s := m.String()
+	return &s
+}
+
+// moneyFromPB decodes the text form of an amount as JSON does; an empty
+// string is zero.
+func moneyFromPB(s string) (models.Money, error) {
+	var m models.Money
+	err := m.UnmarshalText([]byte(s))
+	return m, err
+}
+
+func optionalMoneyFromPB(s *string) (*models.Money, error) {
+	if s == nil {
+		return nil, nil
+	}
+	m, err := moneyFromPB(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func nilIfEmpty(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
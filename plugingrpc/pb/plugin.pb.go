@@ -0,0 +1,1968 @@
+// gRPC form of the UAG plugin contract (contract major version 2).
+//
+// Messages mirror the Go types in the models package field for field; the
+// JSON names used by the REST gateway are the proto field names. Every
+// request names the plugin it is for, as one server can serve several.
+//
+// Regenerate plugingrpc/pb with `task proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: uagplugin/v2/plugin.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListPluginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsRequest) Reset() {
+	*x = ListPluginsRequest{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsRequest) ProtoMessage() {}
+
+func (x *ListPluginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsRequest.ProtoReflect.Descriptor instead.
+func (*ListPluginsRequest) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+type ListPluginsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugins       []*PluginInfo          `protobuf:"bytes,1,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsResponse) Reset() {
+	*x = ListPluginsResponse{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsResponse) ProtoMessage() {}
+
+func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsResponse.ProtoReflect.Descriptor instead.
+func (*ListPluginsResponse) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListPluginsResponse) GetPlugins() []*PluginInfo {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+type PluginInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Transport string                 `protobuf:"bytes,2,opt,name=transport,proto3" json:"transport,omitempty"`
+	Meta      *MetaData              `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
+	// error is set when the plugin's metadata could not be read.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginInfo) Reset() {
+	*x = PluginInfo{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfo) ProtoMessage() {}
+
+func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
+func (*PluginInfo) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PluginInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetMeta() *MetaData {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PluginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugin        string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginRequest) Reset() {
+	*x = PluginRequest{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginRequest) ProtoMessage() {}
+
+func (x *PluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginRequest.ProtoReflect.Descriptor instead.
+func (*PluginRequest) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PluginRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+type CapabilitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authenticator bool                   `protobuf:"varint,1,opt,name=authenticator,proto3" json:"authenticator,omitempty"`
+	Tester        bool                   `protobuf:"varint,2,opt,name=tester,proto3" json:"tester,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesResponse) ProtoMessage() {}
+
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CapabilitiesResponse) GetAuthenticator() bool {
+	if x != nil {
+		return x.Authenticator
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetTester() bool {
+	if x != nil {
+		return x.Tester
+	}
+	return false
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type RunTestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTestsResponse) Reset() {
+	*x = RunTestsResponse{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTestsResponse) ProtoMessage() {}
+
+func (x *RunTestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTestsResponse.ProtoReflect.Descriptor instead.
+func (*RunTestsResponse) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+// PanicInfo is attached to INTERNAL errors for panics recovered in a plugin.
+type PanicInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Stack         string                 `protobuf:"bytes,2,opt,name=stack,proto3" json:"stack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PanicInfo) Reset() {
+	*x = PanicInfo{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PanicInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PanicInfo) ProtoMessage() {}
+
+func (x *PanicInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PanicInfo.ProtoReflect.Descriptor instead.
+func (*PanicInfo) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PanicInfo) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *PanicInfo) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+// models.MetaData
+type MetaData struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version     string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Author      string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	// One of api_key, oauth2 or none.
+	AuthType        string `protobuf:"bytes,6,opt,name=auth_type,json=authType,proto3" json:"auth_type,omitempty"`
+	ContractVersion string `protobuf:"bytes,7,opt,name=contract_version,json=contractVersion,proto3" json:"contract_version,omitempty"`
+	// Declared Auth inputs, key to description.
+	AuthCredentials map[string]string `protobuf:"bytes,8,rep,name=auth_credentials,json=authCredentials,proto3" json:"auth_credentials,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keys Auth must return.
+	ApiCredentials []string      `protobuf:"bytes,9,rep,name=api_credentials,json=apiCredentials,proto3" json:"api_credentials,omitempty"`
+	Oauth2         *OAuth2Config `protobuf:"bytes,10,opt,name=oauth2,proto3" json:"oauth2,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MetaData) Reset() {
+	*x = MetaData{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetaData) ProtoMessage() {}
+
+func (x *MetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetaData.ProtoReflect.Descriptor instead.
+func (*MetaData) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MetaData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MetaData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MetaData) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MetaData) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MetaData) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *MetaData) GetAuthType() string {
+	if x != nil {
+		return x.AuthType
+	}
+	return ""
+}
+
+func (x *MetaData) GetContractVersion() string {
+	if x != nil {
+		return x.ContractVersion
+	}
+	return ""
+}
+
+func (x *MetaData) GetAuthCredentials() map[string]string {
+	if x != nil {
+		return x.AuthCredentials
+	}
+	return nil
+}
+
+func (x *MetaData) GetApiCredentials() []string {
+	if x != nil {
+		return x.ApiCredentials
+	}
+	return nil
+}
+
+func (x *MetaData) GetOauth2() *OAuth2Config {
+	if x != nil {
+		return x.Oauth2
+	}
+	return nil
+}
+
+// models.OAuth2Config
+type OAuth2Config struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorizeUrl  string                 `protobuf:"bytes,1,opt,name=authorize_url,json=authorizeUrl,proto3" json:"authorize_url,omitempty"`
+	TokenUrl      string                 `protobuf:"bytes,2,opt,name=token_url,json=tokenUrl,proto3" json:"token_url,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	AuthUrlParams map[string]string      `protobuf:"bytes,5,rep,name=auth_url_params,json=authUrlParams,proto3" json:"auth_url_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2Config) Reset() {
+	*x = OAuth2Config{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2Config) ProtoMessage() {}
+
+func (x *OAuth2Config) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2Config.ProtoReflect.Descriptor instead.
+func (*OAuth2Config) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OAuth2Config) GetAuthorizeUrl() string {
+	if x != nil {
+		return x.AuthorizeUrl
+	}
+	return ""
+}
+
+func (x *OAuth2Config) GetTokenUrl() string {
+	if x != nil {
+		return x.TokenUrl
+	}
+	return ""
+}
+
+func (x *OAuth2Config) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuth2Config) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuth2Config) GetAuthUrlParams() map[string]string {
+	if x != nil {
+		return x.AuthUrlParams
+	}
+	return nil
+}
+
+// models.AuthParams
+type AuthParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	Data          map[string]string      `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthParams) Reset() {
+	*x = AuthParams{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthParams) ProtoMessage() {}
+
+func (x *AuthParams) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthParams.ProtoReflect.Descriptor instead.
+func (*AuthParams) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AuthParams) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *AuthParams) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *AuthParams) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *AuthParams) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AuthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugin        string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Params        *AuthParams            `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRequest) Reset() {
+	*x = AuthRequest{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRequest) ProtoMessage() {}
+
+func (x *AuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRequest.ProtoReflect.Descriptor instead.
+func (*AuthRequest) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AuthRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *AuthRequest) GetParams() *AuthParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type AuthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// models.AuthCredentials
+	Credentials   map[string]string `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AuthResponse) GetCredentials() map[string]string {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+// models.CommonParams
+type CommonParams struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Cursor         string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit          int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	SortDescending bool                   `protobuf:"varint,4,opt,name=sort_descending,json=sortDescending,proto3" json:"sort_descending,omitempty"`
+	Extras         map[string]string      `protobuf:"bytes,5,rep,name=extras,proto3" json:"extras,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CommonParams) Reset() {
+	*x = CommonParams{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommonParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommonParams) ProtoMessage() {}
+
+func (x *CommonParams) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommonParams.ProtoReflect.Descriptor instead.
+func (*CommonParams) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CommonParams) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *CommonParams) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *CommonParams) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *CommonParams) GetSortDescending() bool {
+	if x != nil {
+		return x.SortDescending
+	}
+	return false
+}
+
+func (x *CommonParams) GetExtras() map[string]string {
+	if x != nil {
+		return x.Extras
+	}
+	return nil
+}
+
+// models.ContactQueryParams
+type ContactQueryParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Common        *CommonParams          `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	Search        string                 `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	SearchIds     []string               `protobuf:"bytes,3,rep,name=search_ids,json=searchIds,proto3" json:"search_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContactQueryParams) Reset() {
+	*x = ContactQueryParams{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContactQueryParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactQueryParams) ProtoMessage() {}
+
+func (x *ContactQueryParams) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactQueryParams.ProtoReflect.Descriptor instead.
+func (*ContactQueryParams) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ContactQueryParams) GetCommon() *CommonParams {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *ContactQueryParams) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ContactQueryParams) GetSearchIds() []string {
+	if x != nil {
+		return x.SearchIds
+	}
+	return nil
+}
+
+// models.LedgerQueryParams
+type LedgerQueryParams struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Common     *CommonParams          `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	CustomerId string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// models.Date text form, "2006-01-02"; empty when unset.
+	StartDate     string   `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string   `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	DocTypes      []string `protobuf:"bytes,5,rep,name=doc_types,json=docTypes,proto3" json:"doc_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerQueryParams) Reset() {
+	*x = LedgerQueryParams{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerQueryParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerQueryParams) ProtoMessage() {}
+
+func (x *LedgerQueryParams) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerQueryParams.ProtoReflect.Descriptor instead.
+func (*LedgerQueryParams) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LedgerQueryParams) GetCommon() *CommonParams {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *LedgerQueryParams) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *LedgerQueryParams) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *LedgerQueryParams) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *LedgerQueryParams) GetDocTypes() []string {
+	if x != nil {
+		return x.DocTypes
+	}
+	return nil
+}
+
+type ContactsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Plugin string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// models.AuthCredentials
+	Auth          map[string]string   `protobuf:"bytes,2,rep,name=auth,proto3" json:"auth,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Params        *ContactQueryParams `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContactsRequest) Reset() {
+	*x = ContactsRequest{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactsRequest) ProtoMessage() {}
+
+func (x *ContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactsRequest.ProtoReflect.Descriptor instead.
+func (*ContactsRequest) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ContactsRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *ContactsRequest) GetAuth() map[string]string {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ContactsRequest) GetParams() *ContactQueryParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type LedgerRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Plugin string                 `protobuf:"bytes,1,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	// models.AuthCredentials
+	Auth          map[string]string  `protobuf:"bytes,2,rep,name=auth,proto3" json:"auth,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Params        *LedgerQueryParams `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerRequest) Reset() {
+	*x = LedgerRequest{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerRequest) ProtoMessage() {}
+
+func (x *LedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerRequest.ProtoReflect.Descriptor instead.
+func (*LedgerRequest) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LedgerRequest) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *LedgerRequest) GetAuth() map[string]string {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *LedgerRequest) GetParams() *LedgerQueryParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// models.Contact
+type Contact struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CompanyName     string                 `protobuf:"bytes,4,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	Phones          []*Phone               `protobuf:"bytes,5,rep,name=phones,proto3" json:"phones,omitempty"`
+	BillingAddress  *Address               `protobuf:"bytes,6,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"`
+	ShippingAddress *Address               `protobuf:"bytes,7,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	TaxId           string                 `protobuf:"bytes,8,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty"`
+	Currency        string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// models.Money text form; unset when the plugin did not supply it.
+	OutstandingBalance *string `protobuf:"bytes,10,opt,name=outstanding_balance,json=outstandingBalance,proto3,oneof" json:"outstanding_balance,omitempty"`
+	// RFC 3339 timestamps; unset when the plugin did not supply them.
+	CreatedAt     *string           `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3,oneof" json:"created_at,omitempty"`
+	UpdatedAt     *string           `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	Attributes    map[string]string `protobuf:"bytes,13,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Contact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *Contact) GetPhones() []*Phone {
+	if x != nil {
+		return x.Phones
+	}
+	return nil
+}
+
+func (x *Contact) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+func (x *Contact) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Contact) GetTaxId() string {
+	if x != nil {
+		return x.TaxId
+	}
+	return ""
+}
+
+func (x *Contact) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Contact) GetOutstandingBalance() string {
+	if x != nil && x.OutstandingBalance != nil {
+		return *x.OutstandingBalance
+	}
+	return ""
+}
+
+func (x *Contact) GetCreatedAt() string {
+	if x != nil && x.CreatedAt != nil {
+		return *x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Contact) GetUpdatedAt() string {
+	if x != nil && x.UpdatedAt != nil {
+		return *x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Contact) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// models.Phone
+type Phone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Phone) Reset() {
+	*x = Phone{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Phone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Phone) ProtoMessage() {}
+
+func (x *Phone) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Phone.ProtoReflect.Descriptor instead.
+func (*Phone) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Phone) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Phone) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+// models.Address
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line1         string                 `protobuf:"bytes,1,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,2,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// models.Contacts
+type Contacts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contacts      []*Contact             `protobuf:"bytes,1,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    *string                `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contacts) Reset() {
+	*x = Contacts{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contacts) ProtoMessage() {}
+
+func (x *Contacts) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contacts.ProtoReflect.Descriptor instead.
+func (*Contacts) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Contacts) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *Contacts) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Contacts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Contacts) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+// models.LedgerEntry
+type LedgerEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// models.Date text form, "2006-01-02".
+	Date    string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	DocType string `protobuf:"bytes,3,opt,name=doc_type,json=docType,proto3" json:"doc_type,omitempty"`
+	// models.Money text form: a decimal and an optional ISO 4217 code, e.g.
+	// "-120.50" or "-120.50 EUR".
+	Amount      string `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reference   string `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	DueDate     string `protobuf:"bytes,7,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// One of debit or credit, or empty.
+	Direction string `protobuf:"bytes,8,opt,name=direction,proto3" json:"direction,omitempty"`
+	Currency  string `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// models.Money text form; unset when the plugin did not supply it.
+	Balance       *string `protobuf:"bytes,10,opt,name=balance,proto3,oneof" json:"balance,omitempty"`
+	LinkedIds     []int64 `protobuf:"varint,11,rep,packed,name=linked_ids,json=linkedIds,proto3" json:"linked_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LedgerEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LedgerEntry) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetDocType() string {
+	if x != nil {
+		return x.DocType
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetBalance() string {
+	if x != nil && x.Balance != nil {
+		return *x.Balance
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetLinkedIds() []int64 {
+	if x != nil {
+		return x.LinkedIds
+	}
+	return nil
+}
+
+// models.Ledger
+type Ledger struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Entries      []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	CustomerName string                 `protobuf:"bytes,2,opt,name=customer_name,json=customerName,proto3" json:"customer_name,omitempty"`
+	// models.Money text form.
+	OpeningBalance string  `protobuf:"bytes,3,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	NextCursor     *string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	// models.Money text form; unset when the plugin did not supply it.
+	ClosingBalance *string `protobuf:"bytes,5,opt,name=closing_balance,json=closingBalance,proto3,oneof" json:"closing_balance,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Ledger) Reset() {
+	*x = Ledger{}
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ledger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ledger) ProtoMessage() {}
+
+func (x *Ledger) ProtoReflect() protoreflect.Message {
+	mi := &file_uagplugin_v2_plugin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ledger.ProtoReflect.Descriptor instead.
+func (*Ledger) Descriptor() ([]byte, []int) {
+	return file_uagplugin_v2_plugin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Ledger) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Ledger) GetCustomerName() string {
+	if x != nil {
+		return x.CustomerName
+	}
+	return ""
+}
+
+func (x *Ledger) GetOpeningBalance() string {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return ""
+}
+
+func (x *Ledger) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+func (x *Ledger) GetClosingBalance() string {
+	if x != nil && x.ClosingBalance != nil {
+		return *x.ClosingBalance
+	}
+	return ""
+}
+
+var File_uagplugin_v2_plugin_proto protoreflect.FileDescriptor
+
+const file_uagplugin_v2_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\x19uagplugin/v2/plugin.proto\x12\fuagplugin.v2\"\x14\n" +
+	"\x12ListPluginsRequest\"I\n" +
+	"\x13ListPluginsResponse\x122\n" +
+	"\aplugins\x18\x01 \x03(\v2\x18.uagplugin.v2.PluginInfoR\aplugins\"|\n" +
+	"\n" +
+	"PluginInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\ttransport\x18\x02 \x01(\tR\ttransport\x12*\n" +
+	"\x04meta\x18\x03 \x01(\v2\x16.uagplugin.v2.MetaDataR\x04meta\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"'\n" +
+	"\rPluginRequest\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\"T\n" +
+	"\x14CapabilitiesResponse\x12$\n" +
+	"\rauthenticator\x18\x01 \x01(\bR\rauthenticator\x12\x16\n" +
+	"\x06tester\x18\x02 \x01(\bR\x06tester\"(\n" +
+	"\x0eHealthResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x12\n" +
+	"\x10RunTestsResponse\"7\n" +
+	"\tPanicInfo\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05stack\x18\x02 \x01(\tR\x05stack\"\xc3\x03\n" +
+	"\bMetaData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1b\n" +
+	"\tauth_type\x18\x06 \x01(\tR\bauthType\x12)\n" +
+	"\x10contract_version\x18\a \x01(\tR\x0fcontractVersion\x12V\n" +
+	"\x10auth_credentials\x18\b \x03(\v2+.uagplugin.v2.MetaData.AuthCredentialsEntryR\x0fauthCredentials\x12'\n" +
+	"\x0fapi_credentials\x18\t \x03(\tR\x0eapiCredentials\x122\n" +
+	"\x06oauth2\x18\n" +
+	" \x01(\v2\x1a.uagplugin.v2.OAuth2ConfigR\x06oauth2\x1aB\n" +
+	"\x14AuthCredentialsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9e\x02\n" +
+	"\fOAuth2Config\x12#\n" +
+	"\rauthorize_url\x18\x01 \x01(\tR\fauthorizeUrl\x12\x1b\n" +
+	"\ttoken_url\x18\x02 \x01(\tR\btokenUrl\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12U\n" +
+	"\x0fauth_url_params\x18\x05 \x03(\v2-.uagplugin.v2.OAuth2Config.AuthUrlParamsEntryR\rauthUrlParams\x1a@\n" +
+	"\x12AuthUrlParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\n" +
+	"AuthParams\x12\x17\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x03 \x01(\tR\fclientSecret\x126\n" +
+	"\x04data\x18\x04 \x03(\v2\".uagplugin.v2.AuthParams.DataEntryR\x04data\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\vAuthRequest\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\x120\n" +
+	"\x06params\x18\x02 \x01(\v2\x18.uagplugin.v2.AuthParamsR\x06params\"\x9d\x01\n" +
+	"\fAuthResponse\x12M\n" +
+	"\vcredentials\x18\x01 \x03(\v2+.uagplugin.v2.AuthResponse.CredentialsEntryR\vcredentials\x1a>\n" +
+	"\x10CredentialsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf4\x01\n" +
+	"\fCommonParams\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12'\n" +
+	"\x0fsort_descending\x18\x04 \x01(\bR\x0esortDescending\x12>\n" +
+	"\x06extras\x18\x05 \x03(\v2&.uagplugin.v2.CommonParams.ExtrasEntryR\x06extras\x1a9\n" +
+	"\vExtrasEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x7f\n" +
+	"\x12ContactQueryParams\x122\n" +
+	"\x06common\x18\x01 \x01(\v2\x1a.uagplugin.v2.CommonParamsR\x06common\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\x12\x1d\n" +
+	"\n" +
+	"search_ids\x18\x03 \x03(\tR\tsearchIds\"\xbf\x01\n" +
+	"\x11LedgerQueryParams\x122\n" +
+	"\x06common\x18\x01 \x01(\v2\x1a.uagplugin.v2.CommonParamsR\x06common\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x1b\n" +
+	"\tdoc_types\x18\x05 \x03(\tR\bdocTypes\"\xd9\x01\n" +
+	"\x0fContactsRequest\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\x12;\n" +
+	"\x04auth\x18\x02 \x03(\v2'.uagplugin.v2.ContactsRequest.AuthEntryR\x04auth\x128\n" +
+	"\x06params\x18\x03 \x01(\v2 .uagplugin.v2.ContactQueryParamsR\x06params\x1a7\n" +
+	"\tAuthEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd4\x01\n" +
+	"\rLedgerRequest\x12\x16\n" +
+	"\x06plugin\x18\x01 \x01(\tR\x06plugin\x129\n" +
+	"\x04auth\x18\x02 \x03(\v2%.uagplugin.v2.LedgerRequest.AuthEntryR\x04auth\x127\n" +
+	"\x06params\x18\x03 \x01(\v2\x1f.uagplugin.v2.LedgerQueryParamsR\x06params\x1a7\n" +
+	"\tAuthEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x05\n" +
+	"\aContact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12!\n" +
+	"\fcompany_name\x18\x04 \x01(\tR\vcompanyName\x12+\n" +
+	"\x06phones\x18\x05 \x03(\v2\x13.uagplugin.v2.PhoneR\x06phones\x12>\n" +
+	"\x0fbilling_address\x18\x06 \x01(\v2\x15.uagplugin.v2.AddressR\x0ebillingAddress\x12@\n" +
+	"\x10shipping_address\x18\a \x01(\v2\x15.uagplugin.v2.AddressR\x0fshippingAddress\x12\x15\n" +
+	"\x06tax_id\x18\b \x01(\tR\x05taxId\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x124\n" +
+	"\x13outstanding_balance\x18\n" +
+	" \x01(\tH\x00R\x12outstandingBalance\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tH\x01R\tcreatedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tH\x02R\tupdatedAt\x88\x01\x01\x12E\n" +
+	"\n" +
+	"attributes\x18\r \x03(\v2%.uagplugin.v2.Contact.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x16\n" +
+	"\x14_outstanding_balanceB\r\n" +
+	"\v_created_atB\r\n" +
+	"\v_updated_at\"3\n" +
+	"\x05Phone\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\"\x9c\x01\n" +
+	"\aAddress\x12\x14\n" +
+	"\x05line1\x18\x01 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x02 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\x05 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\"\x9f\x01\n" +
+	"\bContacts\x121\n" +
+	"\bcontacts\x18\x01 \x03(\v2\x15.uagplugin.v2.ContactR\bcontacts\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12$\n" +
+	"\vnext_cursor\x18\x04 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\"\xc3\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x19\n" +
+	"\bdoc_type\x18\x03 \x01(\tR\adocType\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x19\n" +
+	"\bdue_date\x18\a \x01(\tR\adueDate\x12\x1c\n" +
+	"\tdirection\x18\b \x01(\tR\tdirection\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12\x1d\n" +
+	"\abalance\x18\n" +
+	" \x01(\tH\x00R\abalance\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"linked_ids\x18\v \x03(\x03R\tlinkedIdsB\n" +
+	"\n" +
+	"\b_balance\"\x83\x02\n" +
+	"\x06Ledger\x123\n" +
+	"\aentries\x18\x01 \x03(\v2\x19.uagplugin.v2.LedgerEntryR\aentries\x12#\n" +
+	"\rcustomer_name\x18\x02 \x01(\tR\fcustomerName\x12'\n" +
+	"\x0fopening_balance\x18\x03 \x01(\tR\x0eopeningBalance\x12$\n" +
+	"\vnext_cursor\x18\x04 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01\x12,\n" +
+	"\x0fclosing_balance\x18\x05 \x01(\tH\x01R\x0eclosingBalance\x88\x01\x01B\x0e\n" +
+	"\f_next_cursorB\x12\n" +
+	"\x10_closing_balance2\xbe\x04\n" +
+	"\rPluginService\x12R\n" +
+	"\vListPlugins\x12 .uagplugin.v2.ListPluginsRequest\x1a!.uagplugin.v2.ListPluginsResponse\x12O\n" +
+	"\fCapabilities\x12\x1b.uagplugin.v2.PluginRequest\x1a\".uagplugin.v2.CapabilitiesResponse\x12;\n" +
+	"\x04Meta\x12\x1b.uagplugin.v2.PluginRequest\x1a\x16.uagplugin.v2.MetaData\x12C\n" +
+	"\x06Health\x12\x1b.uagplugin.v2.PluginRequest\x1a\x1c.uagplugin.v2.HealthResponse\x12=\n" +
+	"\x04Auth\x12\x19.uagplugin.v2.AuthRequest\x1a\x1a.uagplugin.v2.AuthResponse\x12A\n" +
+	"\bContacts\x12\x1d.uagplugin.v2.ContactsRequest\x1a\x16.uagplugin.v2.Contacts\x12;\n" +
+	"\x06Ledger\x12\x1b.uagplugin.v2.LedgerRequest\x1a\x14.uagplugin.v2.Ledger\x12G\n" +
+	"\bRunTests\x12\x1b.uagplugin.v2.PluginRequest\x1a\x1e.uagplugin.v2.RunTestsResponseB4Z2github.com/nikhiljohn10/uagplugin/plugingrpc/pb;pbb\x06proto3"
+
+var (
+	file_uagplugin_v2_plugin_proto_rawDescOnce sync.Once
+	file_uagplugin_v2_plugin_proto_rawDescData []byte
+)
+
+func file_uagplugin_v2_plugin_proto_rawDescGZIP() []byte {
+	file_uagplugin_v2_plugin_proto_rawDescOnce.Do(func() {
+		file_uagplugin_v2_plugin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_uagplugin_v2_plugin_proto_rawDesc), len(file_uagplugin_v2_plugin_proto_rawDesc)))
+	})
+	return file_uagplugin_v2_plugin_proto_rawDescData
+}
+
+var file_uagplugin_v2_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_uagplugin_v2_plugin_proto_goTypes = []any{
+	(*ListPluginsRequest)(nil),   // 0: uagplugin.v2.ListPluginsRequest
+	(*ListPluginsResponse)(nil),  // 1: uagplugin.v2.ListPluginsResponse
+	(*PluginInfo)(nil),           // 2: uagplugin.v2.PluginInfo
+	(*PluginRequest)(nil),        // 3: uagplugin.v2.PluginRequest
+	(*CapabilitiesResponse)(nil), // 4: uagplugin.v2.CapabilitiesResponse
+	(*HealthResponse)(nil),       // 5: uagplugin.v2.HealthResponse
+	(*RunTestsResponse)(nil),     // 6: uagplugin.v2.RunTestsResponse
+	(*PanicInfo)(nil),            // 7: uagplugin.v2.PanicInfo
+	(*MetaData)(nil),             // 8: uagplugin.v2.MetaData
+	(*OAuth2Config)(nil),         // 9: uagplugin.v2.OAuth2Config
+	(*AuthParams)(nil),           // 10: uagplugin.v2.AuthParams
+	(*AuthRequest)(nil),          // 11: uagplugin.v2.AuthRequest
+	(*AuthResponse)(nil),         // 12: uagplugin.v2.AuthResponse
+	(*CommonParams)(nil),         // 13: uagplugin.v2.CommonParams
+	(*ContactQueryParams)(nil),   // 14: uagplugin.v2.ContactQueryParams
+	(*LedgerQueryParams)(nil),    // 15: uagplugin.v2.LedgerQueryParams
+	(*ContactsRequest)(nil),      // 16: uagplugin.v2.ContactsRequest
+	(*LedgerRequest)(nil),        // 17: uagplugin.v2.LedgerRequest
+	(*Contact)(nil),              // 18: uagplugin.v2.Contact
+	(*Phone)(nil),                // 19: uagplugin.v2.Phone
+	(*Address)(nil),              // 20: uagplugin.v2.Address
+	(*Contacts)(nil),             // 21: uagplugin.v2.Contacts
+	(*LedgerEntry)(nil),          // 22: uagplugin.v2.LedgerEntry
+	(*Ledger)(nil),               // 23: uagplugin.v2.Ledger
+	nil,                          // 24: uagplugin.v2.MetaData.AuthCredentialsEntry
+	nil,                          // 25: uagplugin.v2.OAuth2Config.AuthUrlParamsEntry
+	nil,                          // 26: uagplugin.v2.AuthParams.DataEntry
+	nil,                          // 27: uagplugin.v2.AuthResponse.CredentialsEntry
+	nil,                          // 28: uagplugin.v2.CommonParams.ExtrasEntry
+	nil,                          // 29: uagplugin.v2.ContactsRequest.AuthEntry
+	nil,                          // 30: uagplugin.v2.LedgerRequest.AuthEntry
+	nil,                          // 31: uagplugin.v2.Contact.AttributesEntry
+}
+var file_uagplugin_v2_plugin_proto_depIdxs = []int32{
+	2,  // 0: uagplugin.v2.ListPluginsResponse.plugins:type_name -> uagplugin.v2.PluginInfo
+	8,  // 1: uagplugin.v2.PluginInfo.meta:type_name -> uagplugin.v2.MetaData
+	24, // 2: uagplugin.v2.MetaData.auth_credentials:type_name -> uagplugin.v2.MetaData.AuthCredentialsEntry
+	9,  // 3: uagplugin.v2.MetaData.oauth2:type_name -> uagplugin.v2.OAuth2Config
+	25, // 4: uagplugin.v2.OAuth2Config.auth_url_params:type_name -> uagplugin.v2.OAuth2Config.AuthUrlParamsEntry
+	26, // 5: uagplugin.v2.AuthParams.data:type_name -> uagplugin.v2.AuthParams.DataEntry
+	10, // 6: uagplugin.v2.AuthRequest.params:type_name -> uagplugin.v2.AuthParams
+	27, // 7: uagplugin.v2.AuthResponse.credentials:type_name -> uagplugin.v2.AuthResponse.CredentialsEntry
+	28, // 8: uagplugin.v2.CommonParams.extras:type_name -> uagplugin.v2.CommonParams.ExtrasEntry
+	13, // 9: uagplugin.v2.ContactQueryParams.common:type_name -> uagplugin.v2.CommonParams
+	13, // 10: uagplugin.v2.LedgerQueryParams.common:type_name -> uagplugin.v2.CommonParams
+	29, // 11: uagplugin.v2.ContactsRequest.auth:type_name -> uagplugin.v2.ContactsRequest.AuthEntry
+	14, // 12: uagplugin.v2.ContactsRequest.params:type_name -> uagplugin.v2.ContactQueryParams
+	30, // 13: uagplugin.v2.LedgerRequest.auth:type_name -> uagplugin.v2.LedgerRequest.AuthEntry
+	15, // 14: uagplugin.v2.LedgerRequest.params:type_name -> uagplugin.v2.LedgerQueryParams
+	19, // 15: uagplugin.v2.Contact.phones:type_name -> uagplugin.v2.Phone
+	20, // 16: uagplugin.v2.Contact.billing_address:type_name -> uagplugin.v2.Address
+	20, // 17: uagplugin.v2.Contact.shipping_address:type_name -> uagplugin.v2.Address
+	31, // 18: uagplugin.v2.Contact.attributes:type_name -> uagplugin.v2.Contact.AttributesEntry
+	18, // 19: uagplugin.v2.Contacts.contacts:type_name -> uagplugin.v2.Contact
+	22, // 20: uagplugin.v2.Ledger.entries:type_name -> uagplugin.v2.LedgerEntry
+	0,  // 21: uagplugin.v2.PluginService.ListPlugins:input_type -> uagplugin.v2.ListPluginsRequest
+	3,  // 22: uagplugin.v2.PluginService.Capabilities:input_type -> uagplugin.v2.PluginRequest
+	3,  // 23: uagplugin.v2.PluginService.Meta:input_type -> uagplugin.v2.PluginRequest
+	3,  // 24: uagplugin.v2.PluginService.Health:input_type -> uagplugin.v2.PluginRequest
+	11, // 25: uagplugin.v2.PluginService.Auth:input_type -> uagplugin.v2.AuthRequest
+	16, // 26: uagplugin.v2.PluginService.Contacts:input_type -> uagplugin.v2.ContactsRequest
+	17, // 27: uagplugin.v2.PluginService.Ledger:input_type -> uagplugin.v2.LedgerRequest
+	3,  // 28: uagplugin.v2.PluginService.RunTests:input_type -> uagplugin.v2.PluginRequest
+	1,  // 29: uagplugin.v2.PluginService.ListPlugins:output_type -> uagplugin.v2.ListPluginsResponse
+	4,  // 30: uagplugin.v2.PluginService.Capabilities:output_type -> uagplugin.v2.CapabilitiesResponse
+	8,  // 31: uagplugin.v2.PluginService.Meta:output_type -> uagplugin.v2.MetaData
+	5,  // 32: uagplugin.v2.PluginService.Health:output_type -> uagplugin.v2.HealthResponse
+	12, // 33: uagplugin.v2.PluginService.Auth:output_type -> uagplugin.v2.AuthResponse
+	21, // 34: uagplugin.v2.PluginService.Contacts:output_type -> uagplugin.v2.Contacts
+	23, // 35: uagplugin.v2.PluginService.Ledger:output_type -> uagplugin.v2.Ledger
+	6,  // 36: uagplugin.v2.PluginService.RunTests:output_type -> uagplugin.v2.RunTestsResponse
+	29, // [29:37] is the sub-list for method output_type
+	21, // [21:29] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_uagplugin_v2_plugin_proto_init() }
+func file_uagplugin_v2_plugin_proto_init() {
+	if File_uagplugin_v2_plugin_proto != nil {
+		return
+	}
+	file_uagplugin_v2_plugin_proto_msgTypes[18].OneofWrappers = []any{}
+	file_uagplugin_v2_plugin_proto_msgTypes[21].OneofWrappers = []any{}
+	file_uagplugin_v2_plugin_proto_msgTypes[22].OneofWrappers = []any{}
+	file_uagplugin_v2_plugin_proto_msgTypes[23].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_uagplugin_v2_plugin_proto_rawDesc), len(file_uagplugin_v2_plugin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   32,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_uagplugin_v2_plugin_proto_goTypes,
+		DependencyIndexes: file_uagplugin_v2_plugin_proto_depIdxs,
+		MessageInfos:      file_uagplugin_v2_plugin_proto_msgTypes,
+	}.Build()
+	File_uagplugin_v2_plugin_proto = out.File
+	file_uagplugin_v2_plugin_proto_goTypes = nil
+	file_uagplugin_v2_plugin_proto_depIdxs = nil
+}
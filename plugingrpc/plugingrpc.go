@@ -0,0 +1,134 @@
+// Package plugingrpc serves loaded plugins over gRPC and connects to plugins
+// served that way.
+//
+// The service is defined in proto/uagplugin/v2/plugin.proto; its generated
+// stubs are in package pb. Server adapts host plugins to the service, and
+// Client adapts a connection back to typing.Plugin, so a remote plugin can
+// be used anywhere a local one can. Importing this package registers the
+// grpc scheme with host.Open:
+//
+//	p, err := host.Open(ctx, "grpc://127.0.0.1:9090/crm")
+//
+// Connections are not encrypted; serve on a loopback address or behind a
+// TLS-terminating proxy.
+package plugingrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc/pb"
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Scheme prefixes targets of remote plugins: grpc://host:port/name.
+const Scheme = "grpc"
+
+// DefaultAddr is the address `uagplugin serve --grpc` listens on by default.
+const DefaultAddr = "127.0.0.1:9090"
+
+func init() {
+	host.RegisterOpener(Scheme, Open)
+}
+
+// ParseTarget splits grpc://host:port/name into the server address and the
+// plugin name.
+func ParseTarget(target string) (addr, name string, err error) {
+	rest, ok := strings.CutPrefix(target, Scheme+"://")
+	if !ok {
+		return "", "", fmt.Errorf("invalid target %q: expected %s://host:port/name", target, Scheme)
+	}
+	addr, name, _ = strings.Cut(rest, "/")
+	if addr == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid target %q: expected %s://host:port/name", target, Scheme)
+	}
+	return addr, name, nil
+}
+
+// Open connects to the plugin named by target. Closing the returned plugin
+// closes the connection.
+func Open(ctx context.Context, target string) (*host.Plugin, error) {
+	c, err := Dial(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	hp := host.Wrap(c)
+	hp.Path, hp.Transport = target, host.TransportGRPC
+	return hp, nil
+}
+
+// toStatus maps a plugin or host error to the status returned to clients.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var pe *pluginrpc.PanicError
+	code := codes.Unknown
+	switch {
+	case errors.As(err, &pe):
+		st, derr := status.New(codes.Internal, pe.Error()).WithDetails(&pb.PanicInfo{Value: pe.Value, Stack: pe.Stack})
+		if derr != nil {
+			return status.Error(codes.Internal, pe.Error())
+		}
+		return st.Err()
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, host.ErrMissingCredentials):
+		code = codes.InvalidArgument
+	case errors.Is(err, host.ErrNoToken):
+		code = codes.Unauthenticated
+	case errors.Is(err, host.ErrNoAuthenticator), errors.Is(err, pluginrpc.ErrUnsupported):
+		code = codes.Unimplemented
+	}
+	return status.Error(code, err.Error())
+}
+
+// remoteError is an error returned by the server, keeping the sentinel its
+// status code stands for so callers can still test for it with errors.Is.
+type remoteError struct {
+	msg string
+	is  error
+}
+
+func (e *remoteError) Error() string { return e.msg }
+func (e *remoteError) Unwrap() error { return e.is }
+
+// fromStatus turns a status from the server back into a plain error: the
+// plugin's message, a *pluginrpc.PanicError for panics, and the sentinel
+// errors toStatus maps from. Transport failures keep their status.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	var is error
+	switch st.Code() {
+	case codes.Internal:
+		for _, d := range st.Details() {
+			if pi, ok := d.(*pb.PanicInfo); ok {
+				return &pluginrpc.PanicError{Value: pi.GetValue(), Stack: pi.GetStack()}
+			}
+		}
+	case codes.DeadlineExceeded:
+		is = context.DeadlineExceeded
+	case codes.Canceled:
+		is = context.Canceled
+	case codes.InvalidArgument:
+		is = host.ErrMissingCredentials
+	case codes.Unauthenticated:
+		is = host.ErrNoToken
+	case codes.Unimplemented:
+		is = pluginrpc.ErrUnsupported
+	case codes.Unknown:
+	default:
+		return err
+	}
+	return &remoteError{msg: st.Message(), is: is}
+}
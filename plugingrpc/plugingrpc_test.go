@@ -0,0 +1,358 @@
+package plugingrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/internal/plugintest"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc/pb"
+	"github.com/nikhiljohn10/uagplugin/pluginrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakePlugin struct{}
+
+func (fakePlugin) Meta() *models.MetaData {
+	keys := models.ApiCredentials{"token"}
+	return &models.MetaData{ID: "fake", Name: "Fake", AuthType: models.AuthTypeAPIKey, ContractVersion: "3.0.0", ApiCredentials: &keys}
+}
+func (fakePlugin) Health() string { return "ok" }
+
+func (fakePlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	switch {
+	case params.Search == "panic":
+		panic("boom")
+	case auth["token"] != "t":
+		return nil, errors.New("unauthorized")
+	}
+	next := "c2"
+	return &models.Contacts{Items: []models.Contact{{ID: "1", Name: params.Search, Email: params.Extras["email"]}}, Count: 1, Total: 2, NextCursor: &next}, nil
+}
+
+func (fakePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	entries := []models.LedgerEntry{}
+	for i, dt := range params.DocTypes {
+		entries = append(entries, models.LedgerEntry{ID: int64(i + 1), Date: params.StartDate, DocType: dt, Amount: models.MustParseMoney("10.50")})
+	}
+	if len(entries) > 1 {
+		balance := models.MustParseMoney("0.00")
+		entries[1].Reference, entries[1].Direction, entries[1].Balance, entries[1].LinkedIDs = "PAY-2", models.DirectionCredit, &balance, []int64{1}
+	}
+	return &models.Ledger{Entries: entries, CustomerName: params.CustomerID, OpeningBalance: models.MustParseMoney("0")}, nil
+}
+
+type authPlugin struct{ fakePlugin }
+
+func (authPlugin) Auth(params models.AuthParams) (*models.AuthCredentials, error) {
+	creds := models.AuthCredentials{}
+	if params.APIKey != "" {
+		creds["token"] = params.APIKey
+	}
+	return &creds, nil
+}
+
+func (authPlugin) RunTests() error { return errors.New("self-test failed") }
+
+func newServer(hook func(context.Context, string, *host.Plugin, models.AuthCredentials) (models.AuthCredentials, error)) *Server {
+	return &Server{
+		Plugins:     map[string]*host.Plugin{"fake": host.Wrap(fakePlugin{}), "auth": host.Wrap(authPlugin{})},
+		Credentials: hook,
+	}
+}
+
+// dial serves srv over an in-memory listener and returns a connection to it.
+func dial(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	srv.Register(gs)
+	go func() { _ = gs.Serve(ln) }()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		gs.Stop()
+	})
+	return conn
+}
+
+func newClient(t *testing.T, conn *grpc.ClientConn, plugin string) *Client {
+	t.Helper()
+	c, err := NewClient(context.Background(), conn, plugin)
+	if err != nil {
+		t.Fatalf("NewClient(%s) error: %v", plugin, err)
+	}
+	return c
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	conn := dial(t, newServer(nil))
+	c := newClient(t, conn, "fake")
+
+	t.Run("should report capabilities", func(t *testing.T) {
+		if caps := c.Capabilities(); caps.Authenticator || caps.Tester || !caps.Context {
+			t.Errorf("Expected only the context capability, got %+v", caps)
+		}
+		if caps := newClient(t, conn, "auth").Capabilities(); !caps.Authenticator || !caps.Tester {
+			t.Errorf("Expected authenticator and tester, got %+v", caps)
+		}
+	})
+
+	t.Run("should return meta and health", func(t *testing.T) {
+		meta := c.Meta()
+		if meta == nil || meta.ID != "fake" || meta.AuthType != models.AuthTypeAPIKey {
+			t.Fatalf("Expected meta of 'fake', got %+v", meta)
+		}
+		if meta.ApiCredentials == nil || (*meta.ApiCredentials)[0] != "token" || meta.AuthCredentials != nil {
+			t.Errorf("Expected api_credentials [token] and no auth_credentials, got %+v", meta)
+		}
+		if h := c.Health(); h != "ok" {
+			t.Errorf("Expected health 'ok', got '%s'", h)
+		}
+	})
+
+	t.Run("should pass auth and params to contacts", func(t *testing.T) {
+		params := models.ContactQueryParams{Search: "alice", CommonParams: models.CommonParams{Extras: map[string]string{"email": "a@example.com"}}}
+		out, err := c.Contacts(models.AuthCredentials{"token": "t"}, params)
+		if err != nil {
+			t.Fatalf("Contacts error: %v", err)
+		}
+		if out.Count != 1 || out.Total != 2 || out.Items[0].Name != "alice" || out.Items[0].Email != "a@example.com" {
+			t.Errorf("Unexpected contacts: %+v", out)
+		}
+		if out.NextCursor == nil || *out.NextCursor != "c2" {
+			t.Errorf("Expected next cursor 'c2', got %v", out.NextCursor)
+		}
+	})
+
+	t.Run("should pass params to ledger", func(t *testing.T) {
+		params := models.LedgerQueryParams{CustomerID: "C1", StartDate: "2024-01-01", DocTypes: []models.DocType{models.DocTypeInvoice, models.DocTypePayment}}
+		out, err := c.Ledger(nil, params)
+		if err != nil {
+			t.Fatalf("Ledger error: %v", err)
+		}
+		if out.CustomerName != "C1" || len(out.Entries) != 2 || out.Entries[1].DocType != models.DocTypePayment || out.Entries[0].Amount.String() != "10.50" {
+			t.Errorf("Unexpected ledger: %+v", out)
+		}
+		if e := out.Entries[1]; e.Reference != "PAY-2" || e.Direction != models.DirectionCredit || e.Balance == nil || e.Balance.String() != "0.00" || len(e.LinkedIDs) != 1 {
+			t.Errorf("Expected statement fields to round-trip, got %+v", e)
+		}
+		if out.Entries[0].Balance != nil || out.ClosingBalance != nil {
+			t.Errorf("Expected unset balances to stay nil, got %+v", out)
+		}
+		if out.NextCursor != nil {
+			t.Errorf("Expected no next cursor, got %q", *out.NextCursor)
+		}
+	})
+
+	t.Run("should call Auth and RunTests", func(t *testing.T) {
+		a := newClient(t, conn, "auth")
+		creds, err := a.Auth(models.AuthParams{APIKey: "t"})
+		if err != nil || (*creds)["token"] != "t" {
+			t.Errorf("Expected token 't', got %v, %v", creds, err)
+		}
+		if err := a.RunTests(); err == nil || err.Error() != "self-test failed" {
+			t.Errorf("Expected 'self-test failed', got %v", err)
+		}
+	})
+}
+
+func TestContactConversion(t *testing.T) {
+	t.Run("should round-trip every contact field", func(t *testing.T) {
+		created, balance := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), models.MustParseMoney("42.10 EUR")
+		in := models.Contact{
+			ID: "7", Name: "Ann", Email: "ann@example.com", CompanyName: "Acme",
+			Phones:         []models.Phone{{Type: "mobile", Number: "+47 400 00 000"}},
+			BillingAddress: &models.Address{Line1: "Main St 1", City: "Oslo", Country: "NO"},
+			TaxID:          "NO123", Currency: "EUR", OutstandingBalance: &balance, CreatedAt: &created,
+			Attributes: map[string]string{"tier": "gold"},
+		}
+		out, err := contactFromPB(contactToPB(in))
+		if err != nil || !reflect.DeepEqual(in, out) {
+			t.Errorf("Expected %+v, got %+v, %v", in, out, err)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	conn := dial(t, newServer(nil))
+	c := newClient(t, conn, "fake")
+
+	t.Run("should reject unknown plugins", func(t *testing.T) {
+		_, err := NewClient(context.Background(), conn, "missing")
+		if status.Code(errors.Unwrap(err)) != codes.NotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+
+	t.Run("should surface plugin errors", func(t *testing.T) {
+		_, err := c.Contacts(nil, models.ContactQueryParams{})
+		if err == nil || err.Error() != "unauthorized" {
+			t.Errorf("Expected 'unauthorized' error, got %v", err)
+		}
+	})
+
+	t.Run("should return panics as PanicError", func(t *testing.T) {
+		_, err := c.Contacts(nil, models.ContactQueryParams{Search: "panic"})
+		var pe *pluginrpc.PanicError
+		if !errors.As(err, &pe) || pe.Value != "boom" || pe.Stack == "" {
+			t.Errorf("Expected PanicError 'boom' with a stack, got %v", err)
+		}
+	})
+
+	t.Run("should report unsupported interfaces", func(t *testing.T) {
+		if _, err := c.Auth(models.AuthParams{}); !errors.Is(err, pluginrpc.ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, got %v", err)
+		}
+		_, err := pb.NewPluginServiceClient(conn).Auth(context.Background(), &pb.AuthRequest{Plugin: "fake"})
+		if status.Code(err) != codes.Unimplemented {
+			t.Errorf("Expected Unimplemented from the server, got %v", err)
+		}
+	})
+
+	t.Run("should keep missing credentials recognisable", func(t *testing.T) {
+		a := newClient(t, conn, "auth")
+		_, err := a.Auth(models.AuthParams{})
+		if !errors.Is(err, host.ErrMissingCredentials) {
+			t.Errorf("Expected ErrMissingCredentials, got %v", err)
+		}
+	})
+
+	t.Run("should forward deadlines", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		if _, err := c.ContactsContext(ctx, nil, models.ContactQueryParams{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+// hangPlugin blocks in Meta, Auth and RunTests until release is closed.
+type hangPlugin struct {
+	fakePlugin
+	release chan struct{}
+}
+
+func (p hangPlugin) Meta() *models.MetaData {
+	<-p.release
+	return p.fakePlugin.Meta()
+}
+
+func (p hangPlugin) Auth(models.AuthParams) (*models.AuthCredentials, error) {
+	<-p.release
+	return &models.AuthCredentials{}, nil
+}
+
+func (p hangPlugin) RunTests() error {
+	<-p.release
+	return nil
+}
+
+func TestClientContexts(t *testing.T) {
+	hang := hangPlugin{release: make(chan struct{})}
+	t.Cleanup(func() { close(hang.release) })
+	conn := dial(t, &Server{Plugins: map[string]*host.Plugin{"hang": host.Wrap(hang)}})
+	c := newClient(t, conn, "hang")
+
+	calls := map[string]func(context.Context) error{
+		"Meta": func(ctx context.Context) error {
+			_, err := c.MetaContext(ctx)
+			return err
+		},
+		"Auth": func(ctx context.Context) error {
+			_, err := c.AuthContext(ctx, models.AuthParams{})
+			return err
+		},
+		"RunTests": c.RunTestsContext,
+	}
+	for name, call := range calls {
+		t.Run("should stop waiting for a hung "+name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if err := call(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected DeadlineExceeded, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCredentialsHook(t *testing.T) {
+	var gotID string
+	conn := dial(t, newServer(func(_ context.Context, id string, _ *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error) {
+		gotID = id
+		auth["token"] = "t"
+		return auth, nil
+	}))
+	c := newClient(t, conn, "fake")
+
+	t.Run("should complete request credentials", func(t *testing.T) {
+		if _, err := c.Contacts(nil, models.ContactQueryParams{}); err != nil {
+			t.Fatalf("Contacts error: %v", err)
+		}
+		if gotID != "fake" {
+			t.Errorf("Expected the hook to get 'fake', got '%s'", gotID)
+		}
+	})
+}
+
+func TestParseTarget(t *testing.T) {
+	t.Run("should split address and plugin name", func(t *testing.T) {
+		addr, name, err := ParseTarget("grpc://127.0.0.1:9090/crm")
+		if err != nil || addr != "127.0.0.1:9090" || name != "crm" {
+			t.Errorf("Expected 127.0.0.1:9090 and crm, got %q %q %v", addr, name, err)
+		}
+	})
+
+	t.Run("should reject incomplete targets", func(t *testing.T) {
+		for _, target := range []string{"grpc://127.0.0.1:9090", "grpc:///crm", "http://host/crm", "grpc://host/a/b"} {
+			if _, _, err := ParseTarget(target); err == nil {
+				t.Errorf("Expected an error for %q", target)
+			}
+		}
+	})
+}
+
+func TestPlugintestRun(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen error: %v", err)
+	}
+	gs := grpc.NewServer()
+	newServer(nil).Register(gs)
+	go func() { _ = gs.Serve(ln) }()
+	t.Cleanup(gs.Stop)
+
+	t.Run("should test a remote plugin like a local one", func(t *testing.T) {
+		target := "grpc://" + ln.Addr().String() + "/fake"
+		res := plugintest.Run(context.Background(), plugintest.RunConfig{
+			Files:   []string{target},
+			Timeout: 5 * time.Second,
+			Auth:    models.AuthCredentials{"token": "t"},
+		})
+		if len(res.Plugins) != 1 {
+			t.Fatalf("Expected 1 plugin result, got %+v", res)
+		}
+		pr := res.Plugins[0]
+		if pr.Name != "fake" || pr.Transport != host.TransportGRPC || pr.File != target {
+			t.Errorf("Unexpected plugin result: %+v", pr)
+		}
+		if res.Failures != 0 {
+			t.Errorf("Expected no failures, got %+v", pr.Funcs)
+		}
+	})
+}
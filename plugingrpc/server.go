@@ -0,0 +1,199 @@
+package plugingrpc
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/host"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/plugingrpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTimeout bounds each plugin call when Server.Timeout is zero and the
+// client sets no deadline.
+const DefaultTimeout = 30 * time.Second
+
+// Server serves Plugins, keyed by the name used in requests, as
+// pb.PluginServiceServer.
+type Server struct {
+	pb.UnimplementedPluginServiceServer
+
+	Plugins map[string]*host.Plugin
+	// Timeout bounds each plugin call (default DefaultTimeout). A shorter
+	// client deadline wins.
+	Timeout time.Duration
+	// Credentials, if set, completes the credentials of a contacts or ledger
+	// request, e.g. with stored profiles or OAuth2 tokens.
+	Credentials func(ctx context.Context, id string, p *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error)
+}
+
+var _ pb.PluginServiceServer = (*Server)(nil)
+
+// Register adds the service to g.
+func (s *Server) Register(g grpc.ServiceRegistrar) {
+	pb.RegisterPluginServiceServer(g, s)
+}
+
+// plugin resolves a request's plugin name and bounds ctx by the timeout.
+func (s *Server) plugin(ctx context.Context, id string) (*host.Plugin, context.Context, context.CancelFunc, error) {
+	p, ok := s.Plugins[id]
+	if !ok {
+		return nil, nil, nil, status.Errorf(codes.NotFound, "plugin %q is not loaded", id)
+	}
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return p, ctx, cancel, nil
+}
+
+func (s *Server) ListPlugins(ctx context.Context, _ *pb.ListPluginsRequest) (*pb.ListPluginsResponse, error) {
+	out := &pb.ListPluginsResponse{}
+	for _, id := range slices.Sorted(maps.Keys(s.Plugins)) {
+		p := s.Plugins[id]
+		info := &pb.PluginInfo{Id: id, Transport: string(p.Transport)}
+		if meta, err := callMeta(ctx, p); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Meta = metaToPB(meta)
+		}
+		out.Plugins = append(out.Plugins, info)
+	}
+	return out, nil
+}
+
+func (s *Server) Capabilities(ctx context.Context, req *pb.PluginRequest) (*pb.CapabilitiesResponse, error) {
+	p, _, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	out := &pb.CapabilitiesResponse{}
+	_, out.Authenticator = p.Authenticator()
+	_, out.Tester = p.Tester()
+	return out, nil
+}
+
+func (s *Server) Meta(ctx context.Context, req *pb.PluginRequest) (*pb.MetaData, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	meta, err := callMeta(ctx, p)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return metaToPB(meta), nil
+}
+
+func (s *Server) Health(ctx context.Context, req *pb.PluginRequest) (*pb.HealthResponse, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	st, err := host.Bounded(ctx, func() (string, error) { return p.HealthContext(ctx), nil })
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.HealthResponse{Status: st}, nil
+}
+
+func (s *Server) Auth(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	params := authParamsFromPB(req.GetParams())
+	creds, err := host.Bounded(ctx, func() (*models.AuthCredentials, error) { return p.AuthenticateContext(ctx, params) })
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := &pb.AuthResponse{}
+	if creds != nil {
+		out.Credentials = *creds
+	}
+	return out, nil
+}
+
+func (s *Server) Contacts(ctx context.Context, req *pb.ContactsRequest) (*pb.Contacts, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	auth, err := s.credentials(ctx, req.GetPlugin(), p, req.GetAuth())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	params := contactParamsFromPB(req.GetParams())
+	out, err := host.Bounded(ctx, func() (*models.Contacts, error) { return p.ContactsContext(ctx, auth, params) })
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if out == nil {
+		return nil, status.Error(codes.Internal, "plugin returned no contacts")
+	}
+	return contactsToPB(out), nil
+}
+
+func (s *Server) Ledger(ctx context.Context, req *pb.LedgerRequest) (*pb.Ledger, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	auth, err := s.credentials(ctx, req.GetPlugin(), p, req.GetAuth())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	params := ledgerParamsFromPB(req.GetParams())
+	out, err := host.Bounded(ctx, func() (*models.Ledger, error) { return p.LedgerContext(ctx, auth, params) })
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if out == nil {
+		return nil, status.Error(codes.Internal, "plugin returned no ledger")
+	}
+	return ledgerToPB(out), nil
+}
+
+func (s *Server) RunTests(ctx context.Context, req *pb.PluginRequest) (*pb.RunTestsResponse, error) {
+	p, ctx, cancel, err := s.plugin(ctx, req.GetPlugin())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	if _, err := host.Bounded(ctx, func() (struct{}, error) { return struct{}{}, p.RunTestsContext(ctx) }); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.RunTestsResponse{}, nil
+}
+
+func (s *Server) credentials(ctx context.Context, id string, p *host.Plugin, auth models.AuthCredentials) (models.AuthCredentials, error) {
+	if auth == nil {
+		auth = models.AuthCredentials{}
+	}
+	if s.Credentials == nil {
+		return auth, nil
+	}
+	return s.Credentials(ctx, id, p, auth)
+}
+
+// callMeta bounds Meta, which takes no context for native plugins, by ctx.
+func callMeta(ctx context.Context, p *host.Plugin) (*models.MetaData, error) {
+	meta, err := host.Bounded(ctx, func() (*models.MetaData, error) { return p.MetaContext(ctx) })
+	if err == nil && meta == nil {
+		err = errors.New("plugin returned no metadata")
+	}
+	return meta, err
+}
@@ -0,0 +1,189 @@
+package pluginrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+// Client talks to a plugin served by ServeConn and implements typing.Plugin,
+// typing.ContextPlugin, typing.Authenticator and typing.Tester. Use
+// Capabilities to find out which optional interfaces the remote side actually
+// supports. Context deadlines are forwarded to the plugin; a cancelled context
+// abandons the pending call and, for plugins that support it, cancels the
+// context the plugin sees.
+type Client struct {
+	rpc    *rpc.Client
+	caps   Capabilities
+	nextID atomic.Uint64
+}
+
+var _ typing.Plugin = (*Client)(nil)
+var _ typing.Authenticator = (*Client)(nil)
+var _ typing.Tester = (*Client)(nil)
+var _ typing.ContextPlugin = (*Client)(nil)
+
+// NewClient performs the capability handshake over conn.
+func NewClient(conn io.ReadWriteCloser) (*Client, error) {
+	c := &Client{rpc: jsonrpc.NewClient(conn)}
+	if err := c.call("Capabilities", Empty{}, &c.caps); err != nil {
+		_ = c.rpc.Close()
+		return nil, fmt.Errorf("plugin handshake failed: %w", err)
+	}
+	return c, nil
+}
+
+func (c *Client) call(method string, args, reply any) error {
+	return c.rpc.Call(ServiceName+"."+method, args, reply)
+}
+
+func (c *Client) callContext(ctx context.Context, method string, opts CallOptions, args, reply any) error {
+	call := c.rpc.Go(ServiceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-ctx.Done():
+		if opts.CallID != 0 {
+			// Fire and forget: the caller has stopped waiting either way.
+			c.rpc.Go(ServiceName+".Cancel", CancelArgs{CallID: opts.CallID}, &Empty{}, make(chan *rpc.Call, 1))
+		}
+		return ctx.Err()
+	}
+}
+
+// callOptions returns the options forwarding ctx to the plugin.
+func (c *Client) callOptions(ctx context.Context) CallOptions {
+	var opts CallOptions
+	if d, ok := ctx.Deadline(); ok {
+		opts.Deadline = &d
+	}
+	if c.caps.Cancel && ctx.Done() != nil {
+		opts.CallID = c.nextID.Add(1)
+	}
+	return opts
+}
+
+// Capabilities reports the optional interfaces implemented by the remote plugin.
+func (c *Client) Capabilities() Capabilities { return c.caps }
+
+// Meta returns nil when the remote call fails; MetaContext reports why.
+func (c *Client) Meta() *models.MetaData {
+	meta, _ := c.MetaContext(context.Background())
+	return meta
+}
+
+// MetaContext returns the remote metadata, or the error of the call.
+func (c *Client) MetaContext(ctx context.Context) (*models.MetaData, error) {
+	var reply MetaReply
+	if err := c.callContext(ctx, "Meta", CallOptions{}, Empty{}, &reply); err != nil {
+		return nil, remoteError(err)
+	}
+	return reply.Meta, nil
+}
+
+func (c *Client) Health() string {
+	return c.HealthContext(context.Background())
+}
+
+// HealthContext returns the remote status, or the transport error text when the call fails.
+func (c *Client) HealthContext(ctx context.Context) string {
+	var reply HealthReply
+	opts := c.callOptions(ctx)
+	if err := c.callContext(ctx, "Health", opts, HealthArgs{CallOptions: opts}, &reply); err != nil {
+		return "error: " + remoteError(err).Error()
+	}
+	return reply.Status
+}
+
+func (c *Client) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	return c.ContactsContext(context.Background(), auth, params)
+}
+
+func (c *Client) ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	var reply ContactsReply
+	args := ContactsArgs{CallOptions: c.callOptions(ctx), Auth: auth, Params: params}
+	if err := c.callContext(ctx, "Contacts", args.CallOptions, args, &reply); err != nil {
+		return nil, remoteError(err)
+	}
+	return reply.Contacts, nil
+}
+
+func (c *Client) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	return c.LedgerContext(context.Background(), auth, params)
+}
+
+func (c *Client) LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	var reply LedgerReply
+	args := LedgerArgs{CallOptions: c.callOptions(ctx), Auth: auth, Params: params}
+	if err := c.callContext(ctx, "Ledger", args.CallOptions, args, &reply); err != nil {
+		return nil, remoteError(err)
+	}
+	return reply.Ledger, nil
+}
+
+func (c *Client) Auth(params models.AuthParams) (*models.AuthCredentials, error) {
+	return c.AuthContext(context.Background(), params)
+}
+
+// AuthContext stops waiting for the plugin when ctx is done.
+func (c *Client) AuthContext(ctx context.Context, params models.AuthParams) (*models.AuthCredentials, error) {
+	if !c.caps.Authenticator {
+		return nil, ErrUnsupported
+	}
+	var reply AuthReply
+	if err := c.callContext(ctx, "Auth", CallOptions{}, params, &reply); err != nil {
+		return nil, remoteError(err)
+	}
+	return reply.Credentials, nil
+}
+
+func (c *Client) RunTests() error {
+	return c.RunTestsContext(context.Background())
+}
+
+// RunTestsContext stops waiting for the plugin when ctx is done.
+func (c *Client) RunTestsContext(ctx context.Context) error {
+	if !c.caps.Tester {
+		return ErrUnsupported
+	}
+	return remoteError(c.callContext(ctx, "RunTests", CallOptions{}, Empty{}, &Empty{}))
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// remoteError strips the net/rpc wrapping from errors returned by the plugin
+// while keeping transport failures (e.g. a crashed plugin) recognisable.
+func remoteError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var se rpc.ServerError
+	if errors.As(err, &se) {
+		if string(se) == ErrUnsupported.Error() {
+			return ErrUnsupported
+		}
+		if data, ok := strings.CutPrefix(string(se), panicPrefix); ok {
+			pe := &PanicError{}
+			if json.Unmarshal([]byte(data), pe) == nil {
+				return pe
+			}
+		}
+		return errors.New(string(se))
+	}
+	if errors.Is(err, rpc.ErrShutdown) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return fmt.Errorf("plugin process exited: %w", err)
+	}
+	return err
+}
@@ -0,0 +1,321 @@
+// Package pluginrpc runs a UAG plugin as a standalone executable that talks to
+// the host over JSON-RPC on stdin/stdout.
+//
+// A plugin built for the RPC runtime exposes the same typing.Plugin value it
+// would export from a shared object; the generated entrypoint simply calls:
+//
+//	func main() { pluginrpc.Main(Plugin) }
+//
+// The host side uses Client, which itself implements typing.Plugin.
+package pluginrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+// ServiceName is the net/rpc service name the plugin registers under.
+const ServiceName = "Plugin"
+
+// ErrUnsupported is returned when the remote plugin does not implement an optional interface.
+var ErrUnsupported = errors.New("method not implemented by plugin")
+
+// panicPrefix marks server errors that carry a recovered panic.
+const panicPrefix = "pluginrpc panic: "
+
+// PanicError reports a panic recovered inside the plugin process. The plugin
+// keeps serving other calls after a panic.
+type PanicError struct {
+	Value string `json:"value"`
+	Stack string `json:"stack"`
+}
+
+func (e *PanicError) Error() string { return "panic: " + e.Value }
+
+// recoverPanic converts a panic in a service method into a PanicError
+// returned to the caller instead of crashing the plugin process.
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		data, _ := json.Marshal(PanicError{Value: fmt.Sprint(r), Stack: string(debug.Stack())})
+		*err = errors.New(panicPrefix + string(data))
+	}
+}
+
+// Capabilities advertises which optional interfaces the remote plugin implements.
+type Capabilities struct {
+	Authenticator bool `json:"authenticator"`
+	Tester        bool `json:"tester"`
+	Context       bool `json:"context"`
+	// Cancel reports that the plugin accepts Cancel calls. Plugins built
+	// before it was added only see the deadline.
+	Cancel bool `json:"cancel"`
+}
+
+// Empty is used for calls without arguments or results.
+type Empty struct{}
+
+type MetaReply struct {
+	Meta *models.MetaData `json:"meta"`
+}
+
+// CallOptions carries the caller's deadline so the plugin can bound its work,
+// and an ID the caller passes to Cancel when its context is cancelled.
+type CallOptions struct {
+	Deadline *time.Time `json:"deadline,omitempty"`
+	CallID   uint64     `json:"call_id,omitempty"`
+}
+
+// CancelArgs names the call to cancel.
+type CancelArgs struct {
+	CallID uint64 `json:"call_id"`
+}
+
+type HealthArgs struct {
+	CallOptions
+}
+
+type HealthReply struct {
+	Status string `json:"status"`
+}
+
+type ContactsArgs struct {
+	CallOptions
+	Auth   models.AuthCredentials    `json:"auth"`
+	Params models.ContactQueryParams `json:"params"`
+}
+
+type ContactsReply struct {
+	Contacts *models.Contacts `json:"contacts"`
+}
+
+type LedgerArgs struct {
+	CallOptions
+	Auth   models.AuthCredentials   `json:"auth"`
+	Params models.LedgerQueryParams `json:"params"`
+}
+
+type LedgerReply struct {
+	Ledger *models.Ledger `json:"ledger"`
+}
+
+type AuthReply struct {
+	Credentials *models.AuthCredentials `json:"credentials"`
+}
+
+// service adapts a typing.Plugin to the method set expected by net/rpc.
+type service struct {
+	impl typing.Plugin
+
+	mu sync.Mutex
+	// running holds the cancel functions of calls in progress by CallID.
+	running map[uint64]context.CancelFunc
+	// early holds Cancel calls that arrived before their call started; net/rpc
+	// runs each request in its own goroutine, so the two can race. Clients
+	// number calls in order, so only IDs above highest, the largest started
+	// so far, can still be early, and entries below it are dropped.
+	early   map[uint64]bool
+	highest uint64
+}
+
+// context returns the context for a call with options o. release must be
+// called when the call returns.
+func (s *service) context(o CallOptions) (ctx context.Context, release func()) {
+	var cancel context.CancelFunc
+	if o.Deadline != nil {
+		ctx, cancel = context.WithDeadline(context.Background(), *o.Deadline)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	if o.CallID == 0 {
+		return ctx, cancel
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	early := s.early[o.CallID]
+	delete(s.early, o.CallID)
+	if o.CallID > s.highest {
+		s.highest = o.CallID
+		for id := range s.early {
+			if id < o.CallID {
+				delete(s.early, id)
+			}
+		}
+	}
+	if early {
+		cancel()
+		return ctx, cancel
+	}
+	if s.running == nil {
+		s.running = map[uint64]context.CancelFunc{}
+	}
+	s.running[o.CallID] = cancel
+	return ctx, func() {
+		s.mu.Lock()
+		delete(s.running, o.CallID)
+		s.mu.Unlock()
+		cancel()
+	}
+}
+
+func (s *service) Capabilities(_ Empty, reply *Capabilities) error {
+	_, reply.Authenticator = s.impl.(typing.Authenticator)
+	_, reply.Tester = s.impl.(typing.Tester)
+	_, reply.Context = s.impl.(typing.ContextPlugin)
+	reply.Cancel = true
+	return nil
+}
+
+// Cancel cancels the context of a call in progress, or of a call that has
+// not started yet. Cancelling a call that has already finished is not an
+// error.
+func (s *service) Cancel(args CancelArgs, _ *Empty) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.running[args.CallID]; ok {
+		cancel()
+		return nil
+	}
+	if args.CallID <= s.highest {
+		return nil
+	}
+	if s.early == nil {
+		s.early = map[uint64]bool{}
+	}
+	s.early[args.CallID] = true
+	return nil
+}
+
+func (s *service) Meta(_ Empty, reply *MetaReply) (err error) {
+	defer recoverPanic(&err)
+	reply.Meta = s.impl.Meta()
+	return nil
+}
+
+func (s *service) Health(args HealthArgs, reply *HealthReply) (err error) {
+	defer recoverPanic(&err)
+	if cp, ok := s.impl.(typing.ContextPlugin); ok {
+		ctx, release := s.context(args.CallOptions)
+		defer release()
+		reply.Status = cp.HealthContext(ctx)
+		return nil
+	}
+	reply.Status = s.impl.Health()
+	return nil
+}
+
+func (s *service) Contacts(args ContactsArgs, reply *ContactsReply) (err error) {
+	defer recoverPanic(&err)
+	var out *models.Contacts
+	if cp, ok := s.impl.(typing.ContextPlugin); ok {
+		ctx, release := s.context(args.CallOptions)
+		defer release()
+		out, err = cp.ContactsContext(ctx, args.Auth, args.Params)
+	} else {
+		out, err = s.impl.Contacts(args.Auth, args.Params)
+	}
+	if err != nil {
+		return err
+	}
+	reply.Contacts = out
+	return nil
+}
+
+func (s *service) Ledger(args LedgerArgs, reply *LedgerReply) (err error) {
+	defer recoverPanic(&err)
+	var out *models.Ledger
+	if cp, ok := s.impl.(typing.ContextPlugin); ok {
+		ctx, release := s.context(args.CallOptions)
+		defer release()
+		out, err = cp.LedgerContext(ctx, args.Auth, args.Params)
+	} else {
+		out, err = s.impl.Ledger(args.Auth, args.Params)
+	}
+	if err != nil {
+		return err
+	}
+	reply.Ledger = out
+	return nil
+}
+
+func (s *service) Auth(args models.AuthParams, reply *AuthReply) (err error) {
+	defer recoverPanic(&err)
+	a, ok := s.impl.(typing.Authenticator)
+	if !ok {
+		return ErrUnsupported
+	}
+	creds, err := a.Auth(args)
+	if err != nil {
+		return err
+	}
+	reply.Credentials = creds
+	return nil
+}
+
+func (s *service) RunTests(_ Empty, _ *Empty) (err error) {
+	defer recoverPanic(&err)
+	t, ok := s.impl.(typing.Tester)
+	if !ok {
+		return ErrUnsupported
+	}
+	return t.RunTests()
+}
+
+// ServeConn serves p on conn until the peer closes the connection.
+func ServeConn(p typing.Plugin, conn io.ReadWriteCloser) error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(ServiceName, &service{impl: p}); err != nil {
+		return err
+	}
+	srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+	return nil
+}
+
+// Serve serves p over the process stdin/stdout. Anything the plugin prints to
+// os.Stdout is redirected to stderr so it cannot corrupt the RPC stream.
+func Serve(p typing.Plugin) error {
+	if p == nil {
+		return errors.New("pluginrpc: nil plugin")
+	}
+	conn := stdioConn{ReadCloser: os.Stdin, WriteCloser: os.Stdout}
+	os.Stdout = os.Stderr
+	return ServeConn(p, conn)
+}
+
+// Main is the entrypoint used by generated RPC plugin executables.
+func Main(p typing.Plugin) {
+	if err := Serve(p); err != nil {
+		_, _ = io.WriteString(os.Stderr, err.Error()+"\n")
+		os.Exit(1)
+	}
+}
+
+type stdioConn struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (c stdioConn) Close() error {
+	rerr := c.ReadCloser.Close()
+	werr := c.WriteCloser.Close()
+	if rerr != nil {
+		return rerr
+	}
+	return werr
+}
+
+// NewConn joins a reader and writer (e.g. process pipes) into a single connection.
+func NewConn(r io.ReadCloser, w io.WriteCloser) io.ReadWriteCloser {
+	return stdioConn{ReadCloser: r, WriteCloser: w}
+}
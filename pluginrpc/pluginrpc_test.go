@@ -0,0 +1,212 @@
+package pluginrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+type fakePlugin struct{}
+
+func (fakePlugin) Meta() *models.MetaData {
+	return &models.MetaData{ID: "fake", Name: "Fake", ContractVersion: "3.0.0"}
+}
+func (fakePlugin) Health() string { return "ok" }
+func (fakePlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	if auth["token"] != "t" {
+		return nil, errors.New("unauthorized")
+	}
+	return &models.Contacts{Items: []models.Contact{{ID: "1", Name: params.Search}}, Count: 1, Total: 1}, nil
+}
+func (fakePlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	return &models.Ledger{CustomerName: params.CustomerID}, nil
+}
+
+type fakeAuthPlugin struct{ fakePlugin }
+
+func (fakeAuthPlugin) Auth(params models.AuthParams) (*models.AuthCredentials, error) {
+	return &models.AuthCredentials{"token": params.APIKey}, nil
+}
+
+func newPair(t *testing.T, p typing.Plugin) *Client {
+	t.Helper()
+	server, client := net.Pipe()
+	go func() { _ = ServeConn(p, server) }()
+	c, err := NewClient(client)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	c := newPair(t, fakePlugin{})
+
+	t.Run("should report capabilities", func(t *testing.T) {
+		if caps := c.Capabilities(); caps.Authenticator || caps.Tester {
+			t.Errorf("Expected no optional capabilities, got %+v", caps)
+		}
+	})
+
+	t.Run("should return meta and health", func(t *testing.T) {
+		meta := c.Meta()
+		if meta == nil || meta.ID != "fake" {
+			t.Fatalf("Expected meta ID 'fake', got %+v", meta)
+		}
+		if h := c.Health(); h != "ok" {
+			t.Errorf("Expected health 'ok', got '%s'", h)
+		}
+	})
+
+	t.Run("should pass auth and params to contacts", func(t *testing.T) {
+		out, err := c.Contacts(models.AuthCredentials{"token": "t"}, models.ContactQueryParams{Search: "alice"})
+		if err != nil {
+			t.Fatalf("Contacts error: %v", err)
+		}
+		if out.Count != 1 || out.Items[0].Name != "alice" {
+			t.Errorf("Unexpected contacts: %+v", out)
+		}
+	})
+
+	t.Run("should surface plugin errors", func(t *testing.T) {
+		_, err := c.Contacts(nil, models.ContactQueryParams{})
+		if err == nil || err.Error() != "unauthorized" {
+			t.Errorf("Expected 'unauthorized' error, got %v", err)
+		}
+	})
+
+	t.Run("should reject unsupported auth", func(t *testing.T) {
+		if _, err := c.Auth(models.AuthParams{}); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, got %v", err)
+		}
+	})
+}
+
+func TestClientAuthenticator(t *testing.T) {
+	c := newPair(t, fakeAuthPlugin{})
+	if !c.Capabilities().Authenticator {
+		t.Fatal("Expected Authenticator capability")
+	}
+	creds, err := c.Auth(models.AuthParams{APIKey: "k"})
+	if err != nil {
+		t.Fatalf("Auth error: %v", err)
+	}
+	if (*creds)["token"] != "k" {
+		t.Errorf("Expected token 'k', got %v", *creds)
+	}
+}
+
+type panicPlugin struct{ fakePlugin }
+
+func (panicPlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	panic("ledger exploded")
+}
+
+func (panicPlugin) Meta() *models.MetaData {
+	panic("meta exploded")
+}
+
+func TestServerRecoversPanics(t *testing.T) {
+	c := newPair(t, panicPlugin{})
+
+	t.Run("should report a panic in Meta", func(t *testing.T) {
+		meta, err := c.MetaContext(context.Background())
+		var pe *PanicError
+		if !errors.As(err, &pe) {
+			t.Fatalf("Expected PanicError, got meta %+v and error %v", meta, err)
+		}
+		if pe.Value != "meta exploded" {
+			t.Errorf("Expected panic value 'meta exploded', got '%s'", pe.Value)
+		}
+	})
+
+	_, err := c.Ledger(nil, models.LedgerQueryParams{})
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected PanicError, got %v", err)
+	}
+	if pe.Value != "ledger exploded" {
+		t.Errorf("Expected panic value 'ledger exploded', got '%s'", pe.Value)
+	}
+	if pe.Stack == "" {
+		t.Error("Expected a stack trace")
+	}
+	if h := c.Health(); h != "ok" {
+		t.Errorf("Expected plugin to keep serving after a panic, got health '%s'", h)
+	}
+}
+
+// blockingPlugin's LedgerContext waits for its context and reports the error.
+type blockingPlugin struct {
+	fakePlugin
+	started chan struct{}
+	stopped chan error
+}
+
+func (p blockingPlugin) HealthContext(ctx context.Context) string { return "ok" }
+func (p blockingPlugin) ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	return p.Contacts(auth, params)
+}
+func (p blockingPlugin) LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	close(p.started)
+	<-ctx.Done()
+	p.stopped <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestClientCancellation(t *testing.T) {
+	t.Run("should cancel the plugin context when the caller cancels", func(t *testing.T) {
+		p := blockingPlugin{started: make(chan struct{}), stopped: make(chan error, 1)}
+		c := newPair(t, p)
+		if !c.Capabilities().Cancel {
+			t.Fatal("Expected Cancel capability")
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-p.started
+			cancel()
+		}()
+		if _, err := c.LedgerContext(ctx, nil, models.LedgerQueryParams{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		select {
+		case err := <-p.stopped:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected the plugin to see context.Canceled, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the plugin context to be cancelled")
+		}
+	})
+
+	t.Run("should cancel calls whose cancel arrives first", func(t *testing.T) {
+		s := &service{impl: fakePlugin{}}
+		_ = s.Cancel(CancelArgs{CallID: 7}, &Empty{})
+		ctx, release := s.context(CallOptions{CallID: 7})
+		defer release()
+		if ctx.Err() == nil {
+			t.Error("Expected the context to be cancelled already")
+		}
+	})
+	t.Run("should not remember cancels of finished or skipped calls", func(t *testing.T) {
+		s := &service{impl: fakePlugin{}}
+		_, release := s.context(CallOptions{CallID: 3})
+		release()
+		_ = s.Cancel(CancelArgs{CallID: 3}, &Empty{})
+		if len(s.early) != 0 {
+			t.Errorf("Expected no early cancel after completion, got %v", s.early)
+		}
+		_ = s.Cancel(CancelArgs{CallID: 4}, &Empty{})
+		ctx, release := s.context(CallOptions{CallID: 5})
+		defer release()
+		if len(s.early) != 0 || ctx.Err() != nil {
+			t.Errorf("Expected the cancel of call 4 to be dropped once call 5 started, got %v", s.early)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package testkit
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/internal/conformance"
+	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+)
+
+// Option customises RunConformance.
+type Option func(*conformanceOptions)
+
+type conformanceOptions struct {
+	cfg  conformance.Config
+	ctx  context.Context
+	skip []string
+}
+
+// WithAuth sets the credentials passed to Contacts and Ledger.
+func WithAuth(auth models.AuthCredentials) Option {
+	return func(o *conformanceOptions) { o.cfg.Auth = auth }
+}
+
+// WithContactParams sets the base params used for every Contacts call.
+func WithContactParams(params models.ContactQueryParams) Option {
+	return func(o *conformanceOptions) { o.cfg.ContactParams = params }
+}
+
+// WithLedgerParams sets the base params used for every Ledger call.
+func WithLedgerParams(params models.LedgerQueryParams) Option {
+	return func(o *conformanceOptions) { o.cfg.LedgerParams = params }
+}
+
+// WithCallTimeout bounds each plugin call made through typing.ContextPlugin.
+func WithCallTimeout(d time.Duration) Option {
+	return func(o *conformanceOptions) { o.cfg.CallTimeout = d }
+}
+
+// WithMaxPages bounds every pagination walk.
+func WithMaxPages(n int) Option {
+	return func(o *conformanceOptions) { o.cfg.MaxPages = n }
+}
+
+// WithContext sets the parent context for all plugin calls.
+func WithContext(ctx context.Context) Option {
+	return func(o *conformanceOptions) { o.ctx = ctx }
+}
+
+// SkipRules skips the named checks (e.g. "ledger.date_range"), for plugins
+// whose backend cannot support them yet.
+func SkipRules(names ...string) Option {
+	return func(o *conformanceOptions) { o.skip = append(o.skip, names...) }
+}
+
+// RunConformance runs the full plugin contract checklist as subtests:
+// metadata completeness, contract version compatibility and the data rules
+// also used by `uagplugin test --mode conformance` (pagination, counts, sort,
+// search and ledger filters).
+//
+//	func TestConformance(t *testing.T) { testkit.RunConformance(t, Plugin) }
+func RunConformance(t *testing.T, p typing.Plugin, opts ...Option) {
+	t.Helper()
+	o := conformanceOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if p == nil {
+		t.Fatal("plugin is nil")
+	}
+
+	meta := p.Meta()
+	t.Run("meta", func(t *testing.T) {
+		if meta == nil {
+			t.Fatal("Meta() returned nil")
+		}
+		required := map[string]string{
+			"ID":              meta.ID,
+			"Name":            meta.Name,
+			"Version":         meta.Version,
+			"Author":          meta.Author,
+			"AuthType":        string(meta.AuthType),
+			"ContractVersion": meta.ContractVersion,
+		}
+		for _, field := range []string{"ID", "Name", "Version", "Author", "AuthType", "ContractVersion"} {
+			if required[field] == "" {
+				t.Errorf("MetaData.%s should not be empty", field)
+			}
+		}
+		if meta.AuthType != "" && !meta.AuthType.Valid() {
+			t.Errorf("MetaData.AuthType %q is not a known models.AuthType", meta.AuthType)
+		}
+	})
+
+	t.Run("contract", func(t *testing.T) {
+		if meta == nil || meta.ContractVersion == "" {
+			t.Skip("no contract version declared")
+		}
+		if !typing.IsCompatible(meta.ContractVersion) {
+			t.Errorf("incompatible contract: %s", typing.IncompatibilityMessage(meta.ContractVersion))
+		}
+	})
+
+	t.Run("health", func(t *testing.T) {
+		if h := p.Health(); h != "ok" {
+			t.Errorf("Health() = %q, want \"ok\"", h)
+		}
+	})
+
+	suite := conformance.NewSuite(p, o.cfg)
+	for _, rule := range conformance.Rules {
+		t.Run(rule.Name, func(t *testing.T) {
+			if slices.Contains(o.skip, rule.Name) {
+				t.Skip("skipped by option")
+			}
+			err := suite.Run(o.ctx, rule)
+			if reason, ok := conformance.SkipReason(err); ok {
+				t.Skip(reason)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@ package testkit
 import (
 	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/nikhiljohn10/uagplugin/models"
+	"github.com/nikhiljohn10/uagplugin/typing"
+	"github.com/nikhiljohn10/uagplugin/utils"
 )
 
 func TestJSONResponse(t *testing.T) {
@@ -39,7 +43,7 @@ func TestJSONResponse(t *testing.T) {
 			ID:      1,
 			Date:    "2025-01-01",
 			DocType: models.DocTypeInvoice,
-			Amount:  "100",
+			Amount:  models.MustParseMoney("100"),
 		}
 		_, url := StartMockServer(map[string]http.Handler{
 			"/ledger": JSONResponse(http.StatusOK, &models.Ledger{
@@ -72,3 +76,64 @@ func TestWithEnv(t *testing.T) {
 		}
 	})
 }
+
+type conformingPlugin struct{}
+
+func (conformingPlugin) Meta() *models.MetaData {
+	return &models.MetaData{
+		ID:              "conforming",
+		Name:            "Conforming",
+		Version:         "1.0.0",
+		Author:          "UAG",
+		AuthType:        models.AuthTypeNone,
+		ContractVersion: typing.ContractVersion,
+	}
+}
+func (conformingPlugin) Health() string { return "ok" }
+func (conformingPlugin) Contacts(auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error) {
+	contacts := []models.Contact{
+		{ID: "1", Name: "Alice Wonderland", Email: "alice@example.com"},
+		{ID: "2", Name: "Bob Builder", Email: "bob@example.com"},
+		{ID: "3", Name: "Carol Singer", Email: "carol@example.com"},
+	}
+	var out []models.Contact
+	for _, c := range contacts {
+		if len(params.SearchIDs) > 0 && !slices.Contains(params.SearchIDs, c.ID) {
+			continue
+		}
+		if params.Search != "" && !strings.Contains(strings.ToLower(c.Name), strings.ToLower(params.Search)) {
+			continue
+		}
+		out = append(out, c)
+	}
+	utils.SortContacts(&out, params.SortDescending)
+	items, next := utils.PaginateCursor(out, params.Cursor, 2)
+	return &models.Contacts{Items: items, Count: len(items), Total: len(out), NextCursor: next}, nil
+}
+func (conformingPlugin) Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error) {
+	entries := []models.LedgerEntry{
+		{ID: 1, Date: "2025-01-03", DocType: models.DocTypeInvoice, Amount: models.MustParseMoney("100.00")},
+		{ID: 2, Date: "2025-01-09", DocType: models.DocTypePayment, Amount: models.MustParseMoney("-60.00")},
+		{ID: 3, Date: "2025-01-15", DocType: models.DocTypeInvoice, Amount: models.MustParseMoney("40.00")},
+		{ID: 4, Date: "2025-01-20", DocType: models.DocTypeCreditNote, Amount: models.MustParseMoney("-10.00")},
+		{ID: 5, Date: "2025-02-02", DocType: models.DocTypeInvoice, Amount: models.MustParseMoney("75.50")},
+		{ID: 6, Date: "2025-02-10", DocType: models.DocTypePayment, Amount: models.MustParseMoney("-115.50")},
+	}
+	inRange, err := utils.FilterByDateRange(entries, params.StartDate, params.EndDate, func(e models.LedgerEntry) string { return e.Date })
+	if err != nil {
+		return nil, err
+	}
+	var out []models.LedgerEntry
+	for _, e := range inRange {
+		if len(params.DocTypes) > 0 && !slices.Contains(params.DocTypes, e.DocType) {
+			continue
+		}
+		out = append(out, e)
+	}
+	items, next := utils.PaginateCursor(out, params.Cursor, 2)
+	return &models.Ledger{Entries: items, OpeningBalance: models.MustParseMoney("0.00"), NextCursor: next}, nil
+}
+
+func TestRunConformance(t *testing.T) {
+	RunConformance(t, conformingPlugin{})
+}
@@ -1,6 +1,10 @@
 package typing
 
-import "github.com/nikhiljohn10/uagplugin/models"
+import (
+	"context"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
 
 // Plugin defines the minimal contract a UAG plugin must implement.
 // Implementations should be exported from the plugin as:
@@ -17,6 +21,17 @@ type Plugin interface {
 	Ledger(auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error)
 }
 
+// ContextPlugin is an optional interface for plugins that honour cancellation.
+// When implemented, the host calls these methods instead of their context-less
+// counterparts, passing a context that carries the per-call deadline and is
+// cancelled on shutdown. Plugins should stop outstanding work (e.g. HTTP
+// requests) when ctx is done.
+type ContextPlugin interface {
+	HealthContext(ctx context.Context) string
+	ContactsContext(ctx context.Context, auth models.AuthCredentials, params models.ContactQueryParams) (*models.Contacts, error)
+	LedgerContext(ctx context.Context, auth models.AuthCredentials, params models.LedgerQueryParams) (*models.Ledger, error)
+}
+
 type Authenticator interface {
 	Auth(params models.AuthParams) (*models.AuthCredentials, error)
 }
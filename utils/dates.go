@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+// FilterByDateRange returns the items whose date falls within start..end,
+// both inclusive, keeping their order. Dates are ISO 8601 strings as in
+// models.LedgerQueryParams; an empty bound is open. When either bound is
+// set, items with an empty date are dropped. A bound or item date that does
+// not parse is an error.
+func FilterByDateRange[T any](items []T, start, end string, date func(T) string) ([]T, error) {
+	from, to, err := models.LedgerQueryParams{StartDate: start, EndDate: end}.ParseDateRange()
+	if err != nil {
+		return nil, err
+	}
+	if from.IsZero() && to.IsZero() {
+		return items, nil
+	}
+	var out []T
+	for i, item := range items {
+		s := date(item)
+		if s == "" {
+			continue
+		}
+		d, err := models.ParseDate(s)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		if !from.IsZero() && d.Before(from) || !to.IsZero() && d.After(to) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func TestFilterByDateRange(t *testing.T) {
+	entries := []models.LedgerEntry{
+		{ID: 1, Date: "2025-01-05"},
+		{ID: 2, Date: "2025-01-10T23:30:00+05:00"},
+		{ID: 3, Date: "2025-02-01"},
+		{ID: 4},
+	}
+	date := func(e models.LedgerEntry) string { return e.Date }
+	ids := func(es []models.LedgerEntry) []int64 {
+		var out []int64
+		for _, e := range es {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	t.Run("should keep everything without bounds", func(t *testing.T) {
+		if got, err := FilterByDateRange(entries, "", "", date); err != nil || len(got) != 4 {
+			t.Errorf("Expected 4 entries, got %v, %v", ids(got), err)
+		}
+	})
+
+	t.Run("should include both bounds", func(t *testing.T) {
+		got, err := FilterByDateRange(entries, "2025-01-05", "2025-01-10", date)
+		if err != nil || len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+			t.Errorf("Expected [1 2], got %v, %v", ids(got), err)
+		}
+	})
+
+	t.Run("should treat an empty bound as open and drop undated entries", func(t *testing.T) {
+		got, err := FilterByDateRange(entries, "", "2025-01-31", date)
+		if err != nil || len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+			t.Errorf("Expected [1 2], got %v, %v", ids(got), err)
+		}
+	})
+
+	t.Run("should report malformed dates", func(t *testing.T) {
+		if _, err := FilterByDateRange(entries, "05/01/2025", "", date); err == nil {
+			t.Error("Expected an error for a malformed bound")
+		}
+		bad := append(slices.Clone(entries), models.LedgerEntry{ID: 5, Date: "05/01/2025"})
+		if _, err := FilterByDateRange(bad, "2025-01-01", "", date); err == nil {
+			t.Error("Expected an error for a malformed entry date")
+		}
+	})
+}
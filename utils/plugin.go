@@ -1,19 +1,144 @@
 package utils
 
 import (
-	"sort"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/nikhiljohn10/uagplugin/models"
 )
 
-func SortContacts(contacts *[]models.Contact, desc bool) {
-	if desc {
-		sort.SliceStable(*contacts, func(i, j int) bool {
-			return (*contacts)[i].Name > (*contacts)[j].Name
-		})
-	} else {
-		sort.SliceStable(*contacts, func(i, j int) bool {
-			return (*contacts)[i].Name < (*contacts)[j].Name
-		})
+// ContactField names a models.Contact field to sort by, using its JSON name.
+// Attribute values are addressed as "attributes.<key>" (see ContactAttribute).
+type ContactField string
+
+const (
+	ContactID                 ContactField = "id"
+	ContactName               ContactField = "name"
+	ContactEmail              ContactField = "email"
+	ContactCompanyName        ContactField = "company_name"
+	ContactPhone              ContactField = "phone"
+	ContactBillingAddress     ContactField = "billing_address"
+	ContactShippingAddress    ContactField = "shipping_address"
+	ContactTaxID              ContactField = "tax_id"
+	ContactCurrency           ContactField = "currency"
+	ContactOutstandingBalance ContactField = "outstanding_balance"
+	ContactCreatedAt          ContactField = "created_at"
+	ContactUpdatedAt          ContactField = "updated_at"
+)
+
+const attributePrefix = "attributes."
+
+// ContactAttribute returns the field sorting by Attributes[key].
+func ContactAttribute(key string) ContactField {
+	return ContactField(attributePrefix + key)
+}
+
+// contactCompare orders two contacts by one field. Phones compare by their
+// first number and addresses by Address.String.
+var contactCompare = map[ContactField]func(a, b *models.Contact) int{
+	ContactID:                 func(a, b *models.Contact) int { return strings.Compare(a.ID, b.ID) },
+	ContactName:               func(a, b *models.Contact) int { return strings.Compare(a.Name, b.Name) },
+	ContactEmail:              func(a, b *models.Contact) int { return strings.Compare(a.Email, b.Email) },
+	ContactCompanyName:        func(a, b *models.Contact) int { return strings.Compare(a.CompanyName, b.CompanyName) },
+	ContactPhone:              func(a, b *models.Contact) int { return strings.Compare(firstPhone(a), firstPhone(b)) },
+	ContactBillingAddress:     func(a, b *models.Contact) int { return compareAddress(a.BillingAddress, b.BillingAddress) },
+	ContactShippingAddress:    func(a, b *models.Contact) int { return compareAddress(a.ShippingAddress, b.ShippingAddress) },
+	ContactTaxID:              func(a, b *models.Contact) int { return strings.Compare(a.TaxID, b.TaxID) },
+	ContactCurrency:           func(a, b *models.Contact) int { return strings.Compare(a.Currency, b.Currency) },
+	ContactOutstandingBalance: func(a, b *models.Contact) int { return compareMoney(a.OutstandingBalance, b.OutstandingBalance) },
+	ContactCreatedAt:          func(a, b *models.Contact) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	ContactUpdatedAt:          func(a, b *models.Contact) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+}
+
+// ParseContactField validates a sort field name, such as one taken from
+// CommonParams.Extras.
+func ParseContactField(s string) (ContactField, error) {
+	f := ContactField(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := contactCompare[f]; ok {
+		return f, nil
+	}
+	if key, ok := strings.CutPrefix(string(f), attributePrefix); ok && key != "" {
+		return ContactAttribute(strings.TrimSpace(s)[len(attributePrefix):]), nil
+	}
+	return "", fmt.Errorf("unknown contact field %q", s)
+}
+
+// SortContacts sorts contacts stably by the given fields in order, later
+// fields breaking ties in earlier ones, or by name when none are given.
+// Missing values sort before present ones, and after them when desc is set.
+// Unknown fields are ignored.
+func SortContacts(contacts *[]models.Contact, desc bool, by ...ContactField) {
+	if len(by) == 0 {
+		by = []ContactField{ContactName}
+	}
+	slices.SortStableFunc(*contacts, func(a, b models.Contact) int {
+		for _, f := range by {
+			if c := compareContacts(f, &a, &b); c != 0 {
+				if desc {
+					return -c
+				}
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+func compareContacts(f ContactField, a, b *models.Contact) int {
+	if cmpFn, ok := contactCompare[f]; ok {
+		return cmpFn(a, b)
+	}
+	if key, ok := strings.CutPrefix(string(f), attributePrefix); ok {
+		return strings.Compare(a.Attributes[key], b.Attributes[key])
+	}
+	return 0
+}
+
+func firstPhone(c *models.Contact) string {
+	if len(c.Phones) == 0 {
+		return ""
+	}
+	return c.Phones[0].Number
+}
+
+func compareAddress(a, b *models.Address) int {
+	var as, bs string
+	if a != nil {
+		as = a.String()
+	}
+	if b != nil {
+		bs = b.String()
+	}
+	return strings.Compare(as, bs)
+}
+
+// compareMoney orders missing amounts first, then by currency code (amounts
+// without one first) and by value within a currency.
+func compareMoney(a, b *models.Money) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if c := strings.Compare(a.Currency(), b.Currency()); c != 0 {
+		return c
+	}
+	return a.Rat().Cmp(b.Rat())
+}
+
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
 	}
+	return a.Compare(*b)
 }
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikhiljohn10/uagplugin/models"
+)
+
+func contactIDs(contacts []models.Contact) string {
+	ids := make([]string, len(contacts))
+	for i, c := range contacts {
+		ids[i] = c.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSortContacts(t *testing.T) {
+	jan, feb := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	owes, credit := models.MustParseMoney("120.00"), models.MustParseMoney("-5")
+	contacts := func() []models.Contact {
+		return []models.Contact{
+			{ID: "1", Name: "Carol", CompanyName: "Acme", CreatedAt: &feb, OutstandingBalance: &owes, Attributes: map[string]string{"tier": "b"}},
+			{ID: "2", Name: "Alice", CompanyName: "Zeta", CreatedAt: &jan, Attributes: map[string]string{"tier": "a"}},
+			{ID: "3", Name: "Bob", CompanyName: "Acme", OutstandingBalance: &credit, BillingAddress: &models.Address{City: "Oslo"}},
+		}
+	}
+
+	t.Run("should sort by name by default", func(t *testing.T) {
+		c := contacts()
+		SortContacts(&c, false)
+		if got := contactIDs(c); got != "2,3,1" {
+			t.Errorf("Expected 2,3,1, got %s", got)
+		}
+		SortContacts(&c, true)
+		if got := contactIDs(c); got != "1,3,2" {
+			t.Errorf("Expected 1,3,2, got %s", got)
+		}
+	})
+
+	t.Run("should break ties with later fields", func(t *testing.T) {
+		c := contacts()
+		SortContacts(&c, false, ContactCompanyName, ContactName)
+		if got := contactIDs(c); got != "3,1,2" {
+			t.Errorf("Expected 3,1,2, got %s", got)
+		}
+	})
+
+	t.Run("should sort missing values first", func(t *testing.T) {
+		cases := map[ContactField]string{
+			ContactCreatedAt:          "3,2,1",
+			ContactOutstandingBalance: "2,3,1",
+			ContactBillingAddress:     "1,2,3",
+			ContactAttribute("tier"):  "3,2,1",
+		}
+		for f, want := range cases {
+			c := contacts()
+			SortContacts(&c, false, f)
+			if got := contactIDs(c); got != want {
+				t.Errorf("Expected %s sorted by %s, got %s", want, f, got)
+			}
+		}
+	})
+
+	t.Run("should sort missing values last when descending", func(t *testing.T) {
+		c := contacts()
+		SortContacts(&c, true, ContactCreatedAt)
+		if got := contactIDs(c); got != "1,2,3" {
+			t.Errorf("Expected 1,2,3, got %s", got)
+		}
+	})
+
+	t.Run("should group balances by currency consistently", func(t *testing.T) {
+		var balances []*models.Money
+		for _, s := range []string{"5 USD", "", "10", "1 EUR", "-3", "2 USD"} {
+			var m *models.Money
+			if s != "" {
+				parsed := models.MustParseMoney(s)
+				m = &parsed
+			}
+			balances = append(balances, m)
+		}
+		var c []models.Contact
+		for i, b := range balances {
+			c = append(c, models.Contact{ID: strconv.Itoa(i), OutstandingBalance: b})
+		}
+		SortContacts(&c, false, ContactOutstandingBalance)
+		if got := contactIDs(c); got != "1,4,2,3,5,0" {
+			t.Errorf("Expected 1,4,2,3,5,0, got %s", got)
+		}
+		for _, a := range balances {
+			for _, b := range balances {
+				if compareMoney(a, b) != -compareMoney(b, a) {
+					t.Errorf("Expected compareMoney(%v, %v) to be antisymmetric", a, b)
+				}
+			}
+		}
+	})
+
+	t.Run("should parse field names", func(t *testing.T) {
+		if f, err := ParseContactField(" Tax_ID "); err != nil || f != ContactTaxID {
+			t.Errorf("Expected tax_id, got %q, %v", f, err)
+		}
+		if f, err := ParseContactField("attributes.Region"); err != nil || f != ContactAttribute("Region") {
+			t.Errorf("Expected attributes.Region, got %q, %v", f, err)
+		}
+		for _, s := range []string{"", "phone_number", "attributes."} {
+			if _, err := ParseContactField(s); err == nil {
+				t.Errorf("Expected an error for %q", s)
+			}
+		}
+	})
+}